@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rahul0tripathi/framecoiner/config"
 	"github.com/rahul0tripathi/framecoiner/controller"
+	v1 "github.com/rahul0tripathi/framecoiner/controller/v1"
 	"github.com/rahul0tripathi/framecoiner/integrations"
 	"github.com/rahul0tripathi/framecoiner/pkg/log"
 	"github.com/rahul0tripathi/framecoiner/pkg/redis"
 	"github.com/rahul0tripathi/framecoiner/pkg/server"
+	"github.com/rahul0tripathi/framecoiner/pkg/session"
 	"github.com/rahul0tripathi/framecoiner/repo"
 	"github.com/rahul0tripathi/framecoiner/services"
 	"go.uber.org/zap"
@@ -56,21 +60,158 @@ func Run() error {
 		return err
 	}
 
-	manager := integrations.NewKeyManager(storage)
-	processor, err := services.NewTradeProcessor(manager, tradesRepo, swapper, chainBackend, logger, cfg.ChainID)
+	manager, err := newKeyManager(cfg, storage)
 	if err != nil {
 		return err
 	}
 
-	accountsSvc := services.NewAccountService(manager, tradesRepo, processor, chainBackend)
+	nonces := services.NewNonceManager(repo.NewNoncesRepo(storage), chainBackend)
+	positionsRepo := repo.NewPositionsRepo(storage)
+	l1Oracle, err := integrations.NewL1FeeOracle(chainBackend, common.HexToAddress(cfg.L1FeeOracle))
+	if err != nil {
+		return err
+	}
+
+	pendingTradesRepo := repo.NewPendingTradesRepo(storage)
+	sweepsRepo := repo.NewSweepsRepo(storage)
+	preferencesRepo := repo.NewPreferencesRepo(storage)
+	processor, err := services.NewTradeProcessor(manager, tradesRepo, swapper, chainBackend, nonces, positionsRepo, pendingTradesRepo, l1Oracle, sweepsRepo, preferencesRepo, logger, services.ProcessorConfig{
+		ChainID:         cfg.ChainID,
+		LegacyTx:        cfg.LegacyTx,
+		MaxFeeCapGwei:   cfg.MaxFeeCapGwei,
+		ResolveInterval: cfg.PendingPollInterval,
+	})
+	if err != nil {
+		return err
+	}
+
+	limits := services.TradeLimits{
+		MaxSlippageBps:    cfg.MaxSlippageBps,
+		MaxPriceImpactBps: cfg.MaxPriceImpactBps,
+		MaxDeadline:       cfg.MaxTradeDeadline,
+	}
+
+	accountsSvc := services.NewAccountService(manager, tradesRepo, processor, chainBackend, limits)
 	metadataSvc := services.NewTokenMetadataService(chainBackend, integrations.ZeroXConfig{
 		ApiKey:  cfg.ZeroXApiKey,
 		ChainID: cfg.ChainID,
 	})
 
-	processor.Run(ctx, 3)
+	quoteSvc, err := services.NewQuoteService(swapper, l1Oracle, repo.NewQuotesRepo(storage), processor, limits, cfg.ChainID)
+	if err != nil {
+		return err
+	}
+
+	ordersRepo := repo.NewOrdersRepo(storage)
+	orderSvc := services.NewOrderService(ordersRepo, tradesRepo)
+	priceWatcher := services.NewPriceWatcher(
+		chainBackend,
+		ordersRepo,
+		metadataSvc,
+		swapper,
+		processor,
+		limits,
+		cfg.OrderPollInterval,
+		logger,
+	)
+
+	positionSvc := services.NewPositionService(positionsRepo, tradesRepo)
+	positionMonitor := services.NewPositionMonitor(
+		chainBackend,
+		manager,
+		positionsRepo,
+		swapper,
+		processor,
+		limits,
+		cfg.PositionPollInterval,
+		logger,
+	)
+
+	schedulesRepo := repo.NewSchedulesRepo(storage)
+	scheduleSvc := services.NewScheduleService(schedulesRepo, tradesRepo)
+	scheduler := services.NewScheduler(schedulesRepo, processor, limits, cfg.SchedulePollInterval, logger)
+
+	copyTradingSvc := services.NewCopyTradingService(repo.NewFollowsRepo(storage), tradesRepo, processor, limits, logger)
+	processor.Subscribe(copyTradingSvc)
+
+	sweepSvc, err := services.NewSweepService(
+		sweepsRepo,
+		positionsRepo,
+		pendingTradesRepo,
+		preferencesRepo,
+		processor,
+		cfg.SweepTokens,
+		cfg.SweepInterval,
+		logger,
+	)
+	if err != nil {
+		return err
+	}
 
-	controller.SetupRouter(accountsSvc, metadataSvc, httpserver.Router())
+	preferencesSvc := services.NewPreferencesService(preferencesRepo)
+
+	sessions, err := session.NewIssuer(cfg.SessionSecret, cfg.SessionTTL)
+	if err != nil {
+		return err
+	}
+
+	chainID, err := strconv.ParseInt(cfg.ChainID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse chainID, %w", err)
+	}
+
+	authSvc := services.NewAuthService(repo.NewAuthRepo(storage), sessions, cfg.SIWEDomain, chainID)
+
+	frameVerifier, err := services.NewFrameVerifier(
+		integrations.NewFarcasterHub(cfg.HubURL),
+		repo.NewFrameActionsRepo(storage),
+		services.FrameVerifierConfig{
+			Mode:      cfg.FrameVerification,
+			Signers:   cfg.FrameSigners,
+			URLPrefix: cfg.FrameURLPrefix,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	walletTradeSvc := services.NewWalletTradeService(swapper, tradesRepo, processor, chainBackend, cfg.ChainID)
+	imageSvc := services.NewImageService(metadataSvc, accountsSvc, repo.NewRendersRepo(storage))
+
+	frameCfg := v1.FrameConfig{
+		PublicURL:     cfg.PublicURL,
+		ExplorerURL:   cfg.ExplorerURL,
+		PresetAmounts: cfg.FramePresetAmounts,
+		NonCustodial:  cfg.FrameNonCustodial,
+		ImageSecret:   cfg.SessionSecret,
+	}
+	if err = frameCfg.Validate(); err != nil {
+		return err
+	}
+
+	processor.Run(ctx, 3)
+	priceWatcher.Run(ctx)
+	positionMonitor.Run(ctx)
+	scheduler.Run(ctx)
+	sweepSvc.Run(ctx)
+
+	controller.SetupRouter(
+		accountsSvc,
+		metadataSvc,
+		quoteSvc,
+		orderSvc,
+		positionSvc,
+		scheduleSvc,
+		copyTradingSvc,
+		sweepSvc,
+		preferencesSvc,
+		authSvc,
+		frameVerifier,
+		imageSvc,
+		walletTradeSvc,
+		frameCfg,
+		httpserver.Router(),
+	)
 
 	httpserver.Start()
 
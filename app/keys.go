@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rahul0tripathi/framecoiner/config"
+	"github.com/rahul0tripathi/framecoiner/integrations"
+	"github.com/rahul0tripathi/framecoiner/pkg/envelope"
+	"github.com/rahul0tripathi/framecoiner/pkg/hdwallet"
+	"github.com/rahul0tripathi/framecoiner/pkg/log"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+	"go.uber.org/zap"
+)
+
+type keyManager interface {
+	LookupSigningAddress(ctx context.Context, owner common.Address) (common.Address, error)
+	SigningAddress(ctx context.Context, owner common.Address) (common.Address, error)
+	SignTx(
+		ctx context.Context,
+		owner common.Address,
+		transaction *types.Transaction,
+		chainID *big.Int,
+	) (*types.Transaction, error)
+}
+
+func newKeyManager(cfg *config.Config, storage integrations.Storage) (keyManager, error) {
+	switch cfg.KeyBackend {
+	case config.KeyBackendRedis:
+		keyEnvelope, err := newKeyEnvelope(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return integrations.NewKeyManager(storage, keyEnvelope), nil
+	case config.KeyBackendHD:
+		seed, err := hdwallet.LoadSeed(cfg.HDSeed, cfg.HDSeedFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load hd seed, %w", err)
+		}
+
+		return integrations.NewHDKeyManager(storage, seed)
+	case config.KeyBackendKeystore:
+		return integrations.NewKeystoreKeyManager(storage, cfg.KeystoreDir, cfg.KeystorePassphrase)
+	case config.KeyBackendRemote:
+		return integrations.NewRemoteSigner(storage, cfg.RemoteSignerURL)
+	default:
+		return nil, fmt.Errorf("unknown key backend %q", cfg.KeyBackend)
+	}
+}
+
+func newKeyEnvelope(cfg *config.Config) (*envelope.Envelope, error) {
+	masterKey, err := envelope.LoadMasterKey(cfg.MasterKey, cfg.MasterKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load master key, %w", err)
+	}
+
+	keyEnvelope, err := envelope.New(cfg.MasterKeyVersion, masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for version, hexKey := range cfg.PreviousMasterKeys {
+		previous, err := envelope.LoadMasterKey(hexKey, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load master key version %d, %w", version, err)
+		}
+
+		if err = keyEnvelope.WithPreviousKey(version, previous); err != nil {
+			return nil, err
+		}
+	}
+
+	return keyEnvelope, nil
+}
+
+// RotateKeys re-wraps every stored trading key under the current master key,
+// starting the scan at cursor.
+func RotateKeys(cursor uint64) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	logger, err := log.NewZapLogger(false)
+	if err != nil {
+		return fmt.Errorf("failed to create logger, %w", err)
+	}
+
+	cfg, err := config.NewConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to get config, %w", err)
+	}
+
+	keyEnvelope, err := newKeyEnvelope(cfg)
+	if err != nil {
+		return err
+	}
+
+	storage, err := redis.NewRedisDB(redis.RedisConfig{
+		Addr:     cfg.RedisAddr,
+		UserName: cfg.RedisUserName,
+		Password: cfg.RedisPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis, %w", err)
+	}
+
+	manager := integrations.NewKeyManager(storage, keyEnvelope)
+	report, err := manager.RotateMasterKey(ctx, cursor)
+	logger.Info("key rotation finished",
+		zap.Int("version", keyEnvelope.Version()),
+		zap.Int("scanned", report.Scanned),
+		zap.Int("rotated", report.Rotated),
+		zap.Int("migrated", report.Migrated),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed),
+		zap.Uint64("cursor", report.Cursor),
+	)
+	if err != nil {
+		return fmt.Errorf("rotation interrupted, resume with -cursor %d, %w", report.Cursor, err)
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("failed to rotate %d records", report.Failed)
+	}
+
+	return nil
+}
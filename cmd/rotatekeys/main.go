@@ -0,0 +1,18 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/rahul0tripathi/framecoiner/app"
+)
+
+func main() {
+	cursor := flag.Uint64("cursor", 0, "scan cursor to resume an interrupted rotation from")
+	flag.Parse()
+
+	err := app.RotateKeys(*cursor)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,15 +1,57 @@
 package config
 
+import "time"
+
+const (
+	KeyBackendRedis    = "redis"
+	KeyBackendHD       = "hd"
+	KeyBackendKeystore = "keystore"
+	KeyBackendRemote   = "remote"
+)
+
 type Config struct {
-	ENV           string `json:"env" envconfig:"ENV"`
-	Host          string `json:"host" envconfig:"HOST"`
-	HostPort      string `json:"hostPort" envconfig:"HOST_PORT"`
-	RedisAddr     string `json:"redisAddr" envconfig:"REDIS_ADDR"`
-	RedisUserName string `json:"redisUserName" envconfig:"REDIS_USERNAME"`
-	RedisPassword string `json:"redisPassword" envconfig:"REDIS_PASSWORD"`
-	ZeroXApiKey   string `json:"zeroXApiKey" envconfig:"ZEROX_KEY"`
-	RpcURL        string `json:"rpcURL" envconfig:"RPC_URL"`
-	ChainID       string `json:"chainID" envconfig:"CHAIN_ID"`
+	ENV                  string         `json:"env" envconfig:"ENV"`
+	Host                 string         `json:"host" envconfig:"HOST"`
+	HostPort             string         `json:"hostPort" envconfig:"HOST_PORT"`
+	RedisAddr            string         `json:"redisAddr" envconfig:"REDIS_ADDR"`
+	RedisUserName        string         `json:"redisUserName" envconfig:"REDIS_USERNAME"`
+	RedisPassword        string         `json:"redisPassword" envconfig:"REDIS_PASSWORD"`
+	ZeroXApiKey          string         `json:"zeroXApiKey" envconfig:"ZEROX_KEY"`
+	RpcURL               string         `json:"rpcURL" envconfig:"RPC_URL"`
+	ChainID              string         `json:"chainID" envconfig:"CHAIN_ID"`
+	MasterKey            string         `json:"-" envconfig:"MASTER_KEY"`
+	MasterKeyFile        string         `json:"masterKeyFile" envconfig:"MASTER_KEY_FILE"`
+	MasterKeyVersion     int            `json:"masterKeyVersion" envconfig:"MASTER_KEY_VERSION" default:"1"`
+	PreviousMasterKeys   map[int]string `json:"-" envconfig:"PREVIOUS_MASTER_KEYS"`
+	KeyBackend           string         `json:"keyBackend" envconfig:"KEY_BACKEND" default:"redis"`
+	HDSeed               string         `json:"-" envconfig:"HD_SEED"`
+	HDSeedFile           string         `json:"hdSeedFile" envconfig:"HD_SEED_FILE"`
+	KeystoreDir          string         `json:"keystoreDir" envconfig:"KEYSTORE_DIR" default:"keystore"`
+	KeystorePassphrase   string         `json:"-" envconfig:"KEYSTORE_PASSPHRASE"`
+	RemoteSignerURL      string         `json:"remoteSignerURL" envconfig:"REMOTE_SIGNER_URL"`
+	LegacyTx             bool           `json:"legacyTx" envconfig:"LEGACY_TX"`
+	MaxFeeCapGwei        string         `json:"maxFeeCapGwei" envconfig:"MAX_FEE_CAP_GWEI"`
+	SIWEDomain           string         `json:"siweDomain" envconfig:"SIWE_DOMAIN"`
+	SessionSecret        string         `json:"-" envconfig:"SESSION_SECRET"`
+	SessionTTL           time.Duration  `json:"sessionTTL" envconfig:"SESSION_TTL" default:"24h"`
+	HubURL               string         `json:"hubURL" envconfig:"HUB_URL" default:"https://nemes.farcaster.xyz:2281"`
+	FrameVerification    string         `json:"frameVerification" envconfig:"FRAME_VERIFICATION" default:"hub"`
+	FrameSigners         []string       `json:"frameSigners" envconfig:"FRAME_SIGNERS"`
+	FrameURLPrefix       string         `json:"frameURLPrefix" envconfig:"FRAME_URL_PREFIX"`
+	PublicURL            string         `json:"publicURL" envconfig:"PUBLIC_URL"`
+	ExplorerURL          string         `json:"explorerURL" envconfig:"EXPLORER_URL" default:"https://basescan.org"`
+	FramePresetAmounts   []string       `json:"framePresetAmounts" envconfig:"FRAME_PRESET_AMOUNTS" default:"0.001,0.005,0.01"`
+	FrameNonCustodial    bool           `json:"frameNonCustodial" envconfig:"FRAME_NON_CUSTODIAL"`
+	MaxSlippageBps       int            `json:"maxSlippageBps" envconfig:"MAX_SLIPPAGE_BPS" default:"500"`
+	MaxPriceImpactBps    int            `json:"maxPriceImpactBps" envconfig:"MAX_PRICE_IMPACT_BPS" default:"4000"`
+	MaxTradeDeadline     time.Duration  `json:"maxTradeDeadline" envconfig:"MAX_TRADE_DEADLINE" default:"10m"`
+	OrderPollInterval    time.Duration  `json:"orderPollInterval" envconfig:"ORDER_POLL_INTERVAL" default:"30s"`
+	PositionPollInterval time.Duration  `json:"positionPollInterval" envconfig:"POSITION_POLL_INTERVAL" default:"30s"`
+	SchedulePollInterval time.Duration  `json:"schedulePollInterval" envconfig:"SCHEDULE_POLL_INTERVAL" default:"15s"`
+	PendingPollInterval  time.Duration  `json:"pendingPollInterval" envconfig:"PENDING_POLL_INTERVAL" default:"30s"`
+	L1FeeOracle          string         `json:"l1FeeOracle" envconfig:"L1_FEE_ORACLE" default:"0x420000000000000000000000000000000000000F"`
+	SweepTokens          []string       `json:"sweepTokens" envconfig:"SWEEP_TOKENS"`
+	SweepInterval        time.Duration  `json:"sweepInterval" envconfig:"SWEEP_INTERVAL" default:"1h"`
 }
 
 func NewConfigFromEnv() (*Config, error) {
@@ -5,12 +5,67 @@ import (
 	"github.com/rahul0tripathi/framecoiner/pkg/server"
 )
 
-func SetupRouter(accountSvc v1.AccountService, tokenMetadataSvc v1.TokenMetadataService, router server.Router) {
+func SetupRouter(
+	accountSvc v1.AccountService,
+	tokenMetadataSvc v1.TokenMetadataService,
+	quoteSvc v1.QuoteService,
+	orderSvc v1.OrderService,
+	positionSvc v1.PositionService,
+	scheduleSvc v1.ScheduleService,
+	copyTradingSvc v1.CopyTradingService,
+	sweepSvc v1.SweepService,
+	preferencesSvc v1.PreferencesService,
+	authSvc v1.AuthService,
+	frameVerifier v1.FrameVerifier,
+	imageSvc v1.TokenImageService,
+	walletTradeSvc v1.WalletTradeService,
+	frameCfg v1.FrameConfig,
+	router server.Router,
+) {
 	handler := v1.NewHandler()
+	ownerAuth := handler.MakeOwnerAuthMiddleware(authSvc)
+	frameAuth := handler.MakeFrameAuthMiddleware(frameVerifier)
 
 	router.GET("/v1", handler.MakeGetFrameCoinerMetadataHandler())
-	router.GET("/v1/account/:owner", handler.MakeGetAccountHandler(accountSvc))
-	router.POST("/v1/account/trade/:owner", handler.MakeTradeRequestHander(accountSvc))
-	router.GET("/v1/account/trades/:owner", handler.MakeLatestTradeHandler(accountSvc))
+	router.GET("/v1/auth/nonce/:owner", handler.MakeNonceHandler(authSvc))
+	router.POST("/v1/auth/verify", handler.MakeVerifyHandler(authSvc))
+	router.GET("/v1/account/:owner", handler.MakeGetAccountHandler(accountSvc), ownerAuth)
+	router.POST("/v1/account/trade/:owner", handler.MakeConfirmTradeHandler(quoteSvc), ownerAuth)
+	router.GET("/v1/account/trades/:owner", handler.MakeLatestTradeHandler(accountSvc), ownerAuth)
+	router.POST("/v1/account/trades/:owner/:txnHash/speed-up", handler.MakeSpeedUpTradeHandler(accountSvc), ownerAuth)
+	router.POST("/v1/account/trades/:owner/:txnHash/cancel", handler.MakeCancelTradeHandler(accountSvc), ownerAuth)
+	router.POST("/v1/account/:owner/withdraw", handler.MakeWithdrawHandler(accountSvc), ownerAuth)
+	router.GET("/v1/account/:owner/preferences", handler.MakeGetPreferencesHandler(preferencesSvc), ownerAuth)
+	router.PATCH("/v1/account/:owner/preferences", handler.MakeSetPreferencesHandler(preferencesSvc), ownerAuth)
+	router.POST("/v1/account/:owner/sweeps", handler.MakeStartSweepHandler(sweepSvc), ownerAuth)
+	router.GET("/v1/account/:owner/sweeps/:sweepID", handler.MakeGetSweepHandler(sweepSvc), ownerAuth)
+	router.POST("/v1/account/:owner/schedules", handler.MakeCreateScheduleHandler(scheduleSvc), ownerAuth)
+	router.GET("/v1/account/:owner/schedules", handler.MakeListSchedulesHandler(scheduleSvc), ownerAuth)
+	router.GET("/v1/account/:owner/schedules/:scheduleID", handler.MakeGetScheduleHandler(scheduleSvc), ownerAuth)
+	router.POST("/v1/account/:owner/schedules/:scheduleID/pause", handler.MakePauseScheduleHandler(scheduleSvc), ownerAuth)
+	router.POST("/v1/account/:owner/schedules/:scheduleID/resume", handler.MakeResumeScheduleHandler(scheduleSvc), ownerAuth)
+	router.DELETE("/v1/account/:owner/schedules/:scheduleID", handler.MakeCancelScheduleHandler(scheduleSvc), ownerAuth)
+	router.POST("/v1/account/:owner/follows", handler.MakeFollowHandler(copyTradingSvc), ownerAuth)
+	router.GET("/v1/account/:owner/follows", handler.MakeListFollowingHandler(copyTradingSvc), ownerAuth)
+	router.GET("/v1/account/:owner/follows/:leader", handler.MakeGetFollowHandler(copyTradingSvc), ownerAuth)
+	router.DELETE("/v1/account/:owner/follows/:leader", handler.MakeUnfollowHandler(copyTradingSvc), ownerAuth)
+	router.GET("/v1/account/:owner/followers", handler.MakeListFollowersHandler(copyTradingSvc), ownerAuth)
+	router.POST("/v1/frame/trade", handler.MakeFrameTradeRequestHandler(accountSvc), frameAuth)
+	router.POST("/v1/orders/:owner", handler.MakePlaceOrderHandler(orderSvc), ownerAuth)
+	router.GET("/v1/orders/:owner", handler.MakeListOrdersHandler(orderSvc), ownerAuth)
+	router.GET("/v1/orders/:owner/:orderID", handler.MakeGetOrderHandler(orderSvc), ownerAuth)
+	router.DELETE("/v1/orders/:owner/:orderID", handler.MakeCancelOrderHandler(orderSvc), ownerAuth)
+	router.GET("/v1/positions/:owner", handler.MakeListPositionsHandler(positionSvc), ownerAuth)
+	router.GET("/v1/positions/:owner/:positionID", handler.MakeGetPositionHandler(positionSvc), ownerAuth)
+	router.PATCH("/v1/positions/:owner/:positionID", handler.MakeSetPositionThresholdsHandler(positionSvc), ownerAuth)
+	router.GET("/v1/quote", handler.MakeQuoteHandler(quoteSvc))
 	router.GET("/v1/metadata/:tokenAddress", handler.MakeGetTokenMetadataHandler(tokenMetadataSvc))
+
+	router.GET("/frames/token/:tokenAddress", handler.MakeTokenFrameHandler(tokenMetadataSvc, frameCfg))
+	router.POST("/frames/token/:tokenAddress", handler.MakeTokenFrameHandler(tokenMetadataSvc, frameCfg))
+	router.POST("/frames/token/:tokenAddress/buy", handler.MakeTokenFrameBuyHandler(accountSvc, tokenMetadataSvc, frameCfg), frameAuth)
+	router.GET("/frames/images/token/:tokenAddress", handler.MakeTokenImageHandler(imageSvc, frameCfg))
+	router.POST("/frames/token/:tokenAddress/status", handler.MakeTokenFrameStatusHandler(accountSvc, tokenMetadataSvc, frameCfg), frameAuth)
+	router.POST("/frames/token/:tokenAddress/tx", handler.MakeTokenFrameTxHandler(walletTradeSvc), frameAuth)
+	router.POST("/frames/token/:tokenAddress/tx-callback", handler.MakeTokenFrameTxCallbackHandler(walletTradeSvc, tokenMetadataSvc, frameCfg), frameAuth)
 }
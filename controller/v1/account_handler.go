@@ -2,20 +2,29 @@ package v1
 
 import "C"
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/labstack/echo/v4"
 	"github.com/rahul0tripathi/framecoiner/entity"
 	"github.com/rahul0tripathi/framecoiner/pkg/server"
 )
 
 const (
-	_paramOwner = "owner"
+	_paramOwner   = "owner"
+	_paramTxnHash = "txnHash"
 
 	_queryBuyAmount        = "amount"
 	_queryDestinationToken = "token"
+
+	_querySlippageBps       = "slippageBps"
+	_queryMaxPriceImpactBps = "maxPriceImpactBps"
+	_queryDeadline          = "deadline"
 )
 
 func (h *Handler) MakeGetAccountHandler(svc AccountService) echo.HandlerFunc {
@@ -44,7 +53,14 @@ func (h *Handler) MakeGetAccountHandler(svc AccountService) echo.HandlerFunc {
 	}
 }
 
-func (h *Handler) MakeTradeRequestHander(svc AccountService) echo.HandlerFunc {
+type confirmTradeRequest struct {
+	QuoteID   string `json:"quoteId"`
+	Signature string `json:"signature"`
+}
+
+// MakeConfirmTradeHandler places the trade for a quote preview the owner
+// signed, trades are no longer re-quoted from bare amounts on this route.
+func (h *Handler) MakeConfirmTradeHandler(svc QuoteService) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		owner := c.Param(_paramOwner)
 		if !common.IsHexAddress(owner) {
@@ -53,10 +69,126 @@ func (h *Handler) MakeTradeRequestHander(svc AccountService) echo.HandlerFunc {
 			})
 		}
 
-		buyAmount := c.QueryParam(_queryBuyAmount)
-		tokenAddress := c.QueryParam(_queryDestinationToken)
+		request := &confirmTradeRequest{}
+		if err := c.Bind(request); err != nil || request.QuoteID == "" || request.Signature == "" {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "quoteId and signature are required",
+			})
+		}
 
-		err := svc.PlaceTradeRequest(c.Request().Context(), common.HexToAddress(owner), common.HexToAddress(tokenAddress), buyAmount)
+		err := svc.ConfirmTrade(c.Request().Context(), common.HexToAddress(owner), request.QuoteID, request.Signature)
+		switch {
+		case err == nil:
+		case errors.Is(err, entity.ErrNoQuoteTerms), errors.Is(err, entity.ErrQuoteExpired):
+			return server.ResponseJSON(c, http.StatusGone, map[string]interface{}{
+				"error": err.Error(),
+			})
+		case errors.Is(err, entity.ErrInvalidQuoteSignature):
+			return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return tradeRequestResponse(c, err)
+	}
+}
+
+// tradeControls reads the optional slippage, price impact and unix deadline
+// query params, unset params stay zero for the service to default.
+func tradeControls(c echo.Context) (entity.TradeControls, error) {
+	controls := entity.TradeControls{}
+	for param, target := range map[string]*int{
+		_querySlippageBps:       &controls.SlippageBps,
+		_queryMaxPriceImpactBps: &controls.MaxPriceImpactBps,
+	} {
+		raw := c.QueryParam(param)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return controls, fmt.Errorf("invalid %s", param)
+		}
+
+		*target = value
+	}
+
+	if raw := c.QueryParam(_queryDeadline); raw != "" {
+		deadline, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return controls, fmt.Errorf("invalid %s", _queryDeadline)
+		}
+
+		controls.Deadline = deadline
+	}
+
+	return controls, nil
+}
+
+type withdrawRequest struct {
+	Amount string `json:"amount"`
+}
+
+func (h *Handler) MakeWithdrawHandler(svc AccountService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		request := &withdrawRequest{}
+		if err := c.Bind(request); err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "amount in wei or \"max\" is required",
+			})
+		}
+
+		err := svc.Withdraw(c.Request().Context(), common.HexToAddress(owner), request.Amount)
+		return tradeRequestResponse(c, err)
+	}
+}
+
+func tradeRequestResponse(c echo.Context, err error) error {
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrInvalidSellAmount),
+		errors.Is(err, entity.ErrInvalidSwapPair),
+		errors.Is(err, entity.ErrInvalidTradeControls),
+		errors.Is(err, entity.ErrInvalidWithdrawal),
+		errors.Is(err, entity.ErrNothingToWithdraw):
+		return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrNoAccountFound):
+		return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
+			"error": "account not found",
+		})
+	case err != nil:
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"relayed": true,
+		},
+	})
+}
+
+func (h *Handler) MakeLatestTradeHandler(svc AccountService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		trade, err := svc.LatestTrade(c.Request().Context(), common.HexToAddress(owner))
 		switch {
 		case err == nil:
 		case errors.Is(err, entity.ErrNoAccountFound):
@@ -71,13 +203,23 @@ func (h *Handler) MakeTradeRequestHander(svc AccountService) echo.HandlerFunc {
 
 		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
 			"data": map[string]interface{}{
-				"relayed": true,
+				"trade": trade,
 			},
 		})
 	}
 }
 
-func (h *Handler) MakeLatestTradeHandler(svc AccountService) echo.HandlerFunc {
+func (h *Handler) MakeSpeedUpTradeHandler(svc AccountService) echo.HandlerFunc {
+	return h.makeReplaceTradeHandler(svc.SpeedUpTrade)
+}
+
+func (h *Handler) MakeCancelTradeHandler(svc AccountService) echo.HandlerFunc {
+	return h.makeReplaceTradeHandler(svc.CancelTrade)
+}
+
+func (h *Handler) makeReplaceTradeHandler(
+	replace func(ctx context.Context, address common.Address, txnHash common.Hash) (*entity.Trade, error),
+) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		owner := c.Param(_paramOwner)
 		if !common.IsHexAddress(owner) {
@@ -86,12 +228,23 @@ func (h *Handler) MakeLatestTradeHandler(svc AccountService) echo.HandlerFunc {
 			})
 		}
 
-		trade, err := svc.LatestTrade(c.Request().Context(), common.HexToAddress(owner))
+		hash, err := hexutil.Decode(c.Param(_paramTxnHash))
+		if err != nil || len(hash) != common.HashLength {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid transaction hash",
+			})
+		}
+
+		trade, err := replace(c.Request().Context(), common.HexToAddress(owner), common.BytesToHash(hash))
 		switch {
 		case err == nil:
-		case errors.Is(err, entity.ErrNoAccountFound):
+		case errors.Is(err, entity.ErrNoPendingTrade):
 			return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
-				"error": "account not found",
+				"error": err.Error(),
+			})
+		case errors.Is(err, entity.ErrTradeNotPending), errors.Is(err, entity.ErrLockNotAcquired):
+			return server.ResponseJSON(c, http.StatusConflict, map[string]interface{}{
+				"error": err.Error(),
 			})
 		case err != nil:
 			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
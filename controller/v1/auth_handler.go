@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_bearerPrefix = "Bearer "
+)
+
+type verifyRequest struct {
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+func (h *Handler) MakeNonceHandler(svc AuthService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		nonce, err := svc.Nonce(c.Request().Context(), common.HexToAddress(owner))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"nonce": nonce,
+			},
+		})
+	}
+}
+
+func (h *Handler) MakeVerifyHandler(svc AuthService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := &verifyRequest{}
+		if err := c.Bind(request); err != nil || request.Message == "" || request.Signature == "" {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "message and signature are required",
+			})
+		}
+
+		session, err := svc.Verify(c.Request().Context(), request.Message, request.Signature)
+		switch {
+		case err == nil:
+		case errors.Is(err, entity.ErrUnauthorized):
+			return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+				"error": err.Error(),
+			})
+		case err != nil:
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": session,
+		})
+	}
+}
+
+// MakeOwnerAuthMiddleware rejects requests whose session token does not
+// belong to the owner in the route path.
+func (h *Handler) MakeOwnerAuthMiddleware(svc AuthService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+			if !strings.HasPrefix(authorization, _bearerPrefix) {
+				return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+					"error": "missing session token",
+				})
+			}
+
+			owner, err := svc.Authenticate(c.Request().Context(), strings.TrimPrefix(authorization, _bearerPrefix))
+			if err != nil {
+				return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+
+			path := c.Param(_paramOwner)
+			if !common.IsHexAddress(path) || common.HexToAddress(path) != owner {
+				return server.ResponseJSON(c, http.StatusForbidden, map[string]interface{}{
+					"error": "session does not belong to owner",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
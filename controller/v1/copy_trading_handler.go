@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_paramLeader = "leader"
+)
+
+type followRequest struct {
+	Leader string `json:"leader"`
+	entity.FollowTerms
+}
+
+func (h *Handler) MakeFollowHandler(svc CopyTradingService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		request := &followRequest{}
+		if err := c.Bind(request); err != nil || !common.IsHexAddress(request.Leader) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "leader and either ethIn or ratioBps are required",
+			})
+		}
+
+		follow, err := svc.FollowLeader(
+			c.Request().Context(),
+			common.HexToAddress(owner),
+			common.HexToAddress(request.Leader),
+			request.FollowTerms,
+		)
+
+		return followResponse(c, follow, err)
+	}
+}
+
+func (h *Handler) MakeListFollowingHandler(svc CopyTradingService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		follows, err := svc.Following(c.Request().Context(), common.HexToAddress(owner))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"following": follows,
+			},
+		})
+	}
+}
+
+func (h *Handler) MakeListFollowersHandler(svc CopyTradingService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		follows, err := svc.Followers(c.Request().Context(), common.HexToAddress(owner))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"followers": follows,
+			},
+		})
+	}
+}
+
+func (h *Handler) MakeGetFollowHandler(svc CopyTradingService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner, leader := c.Param(_paramOwner), c.Param(_paramLeader)
+		if !common.IsHexAddress(owner) || !common.IsHexAddress(leader) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner or leader address",
+			})
+		}
+
+		follow, err := svc.FollowDetails(c.Request().Context(), common.HexToAddress(owner), common.HexToAddress(leader))
+		return followResponse(c, follow, err)
+	}
+}
+
+func (h *Handler) MakeUnfollowHandler(svc CopyTradingService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner, leader := c.Param(_paramOwner), c.Param(_paramLeader)
+		if !common.IsHexAddress(owner) || !common.IsHexAddress(leader) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner or leader address",
+			})
+		}
+
+		err := svc.UnfollowLeader(c.Request().Context(), common.HexToAddress(owner), common.HexToAddress(leader))
+		return followResponse(c, nil, err)
+	}
+}
+
+func followResponse(c echo.Context, follow *entity.Follow, err error) error {
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrInvalidFollowTerms), errors.Is(err, entity.ErrInvalidTradeControls):
+		return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrNoFollowFound):
+		return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrLockNotAcquired):
+		return server.ResponseJSON(c, http.StatusConflict, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case err != nil:
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"follow": follow,
+		},
+	})
+}
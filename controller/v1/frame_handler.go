@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_contextFrameAction = "frameAction"
+)
+
+// MakeFrameAuthMiddleware verifies the signed frame action in the request
+// body and exposes it, along with the owner it resolves to, to the handler.
+func (h *Handler) MakeFrameAuthMiddleware(svc FrameVerifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			payload := &entity.FramePayload{}
+			if err := c.Bind(payload); err != nil || payload.TrustedData.MessageBytes == "" {
+				return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+					"error": "invalid frame payload",
+				})
+			}
+
+			action, err := svc.VerifyFrameAction(c.Request().Context(), payload)
+			switch {
+			case err == nil:
+			case errors.Is(err, entity.ErrInvalidFrameAction), errors.Is(err, entity.ErrNoFrameOwner):
+				return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+					"error": err.Error(),
+				})
+			case err != nil:
+				return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+
+			c.Set(_contextFrameAction, action)
+			return next(c)
+		}
+	}
+}
+
+func (h *Handler) MakeFrameTradeRequestHandler(svc AccountService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		action := frameAction(c)
+		signed, err := signedQuery(action)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid frame url",
+			})
+		}
+
+		buyAmount := signed.Get(_queryBuyAmount)
+		tokenAddress := signed.Get(_queryDestinationToken)
+		if !common.IsHexAddress(tokenAddress) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid token address",
+			})
+		}
+
+		err = svc.PlaceTradeRequest(c.Request().Context(), common.HexToAddress(action.Owner), common.HexToAddress(tokenAddress), buyAmount)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"relayed": true,
+				"owner":   action.Owner,
+			},
+		})
+	}
+}
+
+func frameAction(c echo.Context) *entity.FrameAction {
+	action, _ := c.Get(_contextFrameAction).(*entity.FrameAction)
+	return action
+}
+
+// signedFrameToken returns the token of the /frames/token/:tokenAddress url
+// the frame action was signed for.
+func signedFrameToken(action *entity.FrameAction) (common.Address, bool) {
+	parsed, err := url.Parse(action.URL)
+	if err != nil {
+		return common.Address{}, false
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i := 0; i+2 < len(segments); i++ {
+		if segments[i] == "frames" && segments[i+1] == "token" && common.IsHexAddress(segments[i+2]) {
+			return common.HexToAddress(segments[i+2]), true
+		}
+	}
+
+	return common.Address{}, false
+}
+
+// signedQuery returns the query of the url the frame action was signed for,
+// the query of the request itself is not covered by the signature.
+func signedQuery(action *entity.FrameAction) (url.Values, error) {
+	parsed, err := url.Parse(action.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.Query(), nil
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"crypto/hmac"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_queryOwner     = "owner"
+	_querySignature = "sig"
+	_queryAspect    = "aspect"
+
+	_aspectWide        = "wide"
+	_imageCacheControl = "public, max-age=60"
+)
+
+func (h *Handler) MakeTokenImageHandler(svc TokenImageService, cfg FrameConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Param(_paramTokenAddress)
+		if !common.IsHexAddress(token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid token address",
+			})
+		}
+
+		request := &entity.TokenImageRequest{
+			Token: common.HexToAddress(token),
+			Wide:  c.QueryParam(_queryAspect) == _aspectWide,
+		}
+
+		if owner := c.QueryParam(_queryOwner); owner != "" {
+			if !common.IsHexAddress(owner) {
+				return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+					"error": "invalid owner address",
+				})
+			}
+
+			ownerAddress := common.HexToAddress(owner)
+			signature := cfg.ownerImageSignature(request.Token, ownerAddress)
+			if !hmac.Equal([]byte(c.QueryParam(_querySignature)), []byte(signature)) {
+				return server.ResponseJSON(c, http.StatusForbidden, map[string]interface{}{
+					"error": "invalid owner signature",
+				})
+			}
+
+			request.Owner = &ownerAddress
+		}
+
+		rendered, err := svc.RenderTokenImage(c.Request().Context(), request)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		etag := `"` + rendered.ETag + `"`
+		c.Response().Header().Set("ETag", etag)
+		c.Response().Header().Set("Cache-Control", _imageCacheControl)
+		if c.Request().Header.Get("If-None-Match") == etag {
+			return c.NoContent(http.StatusNotModified)
+		}
+
+		return server.ResponseBlob(c, http.StatusOK, "image/png", rendered.PNG)
+	}
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rahul0tripathi/framecoiner/entity"
@@ -22,8 +23,116 @@ type AccountService interface {
 		ctx context.Context,
 		address common.Address,
 	) (*entity.Trade, error)
+	SpeedUpTrade(ctx context.Context, address common.Address, txnHash common.Hash) (*entity.Trade, error)
+	CancelTrade(ctx context.Context, address common.Address, txnHash common.Hash) (*entity.Trade, error)
+	Withdraw(ctx context.Context, address common.Address, amount string) error
+}
+
+type QuoteService interface {
+	Preview(
+		ctx context.Context,
+		owner common.Address,
+		sellToken common.Address,
+		buyToken common.Address,
+		amount string,
+		controls entity.TradeControls,
+		hold bool,
+		thresholds entity.PositionThresholds,
+	) (*entity.QuotePreview, error)
+	ConfirmTrade(ctx context.Context, owner common.Address, quoteID string, signature string) error
+}
+
+type OrderService interface {
+	PlaceLimitOrder(
+		ctx context.Context,
+		owner common.Address,
+		token common.Address,
+		ethIn string,
+		limitPrice string,
+	) (*entity.LimitOrder, error)
+	Orders(ctx context.Context, owner common.Address) ([]*entity.LimitOrder, error)
+	Order(ctx context.Context, owner common.Address, id string) (*entity.LimitOrder, error)
+	CancelOrder(ctx context.Context, owner common.Address, id string) (*entity.LimitOrder, error)
+}
+
+type PositionService interface {
+	Positions(ctx context.Context, owner common.Address) ([]*entity.Position, error)
+	Position(ctx context.Context, owner common.Address, id string) (*entity.Position, error)
+	SetThresholds(
+		ctx context.Context,
+		owner common.Address,
+		id string,
+		stopLossBps int,
+		takeProfitBps int,
+	) (*entity.Position, error)
+}
+
+type ScheduleService interface {
+	CreateSchedule(
+		ctx context.Context,
+		owner common.Address,
+		token common.Address,
+		ethIn string,
+		interval time.Duration,
+		runs int,
+		missedRunPolicy string,
+	) (*entity.Schedule, error)
+	Schedules(ctx context.Context, owner common.Address) ([]*entity.Schedule, error)
+	Schedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error)
+	PauseSchedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error)
+	ResumeSchedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error)
+	CancelSchedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error)
+}
+
+type CopyTradingService interface {
+	FollowLeader(
+		ctx context.Context,
+		follower common.Address,
+		leader common.Address,
+		terms entity.FollowTerms,
+	) (*entity.Follow, error)
+	UnfollowLeader(ctx context.Context, follower common.Address, leader common.Address) error
+	Following(ctx context.Context, follower common.Address) ([]*entity.Follow, error)
+	Followers(ctx context.Context, leader common.Address) ([]*entity.Follow, error)
+	FollowDetails(ctx context.Context, follower common.Address, leader common.Address) (*entity.Follow, error)
+}
+
+type SweepService interface {
+	StartSweep(ctx context.Context, owner common.Address) (*entity.Sweep, error)
+	Sweep(ctx context.Context, owner common.Address, id string) (*entity.Sweep, error)
+}
+
+type PreferencesService interface {
+	Preferences(ctx context.Context, owner common.Address) (*entity.Preferences, error)
+	SetHoldingMode(ctx context.Context, owner common.Address, mode string, recipient string) (*entity.Preferences, error)
 }
 
 type TokenMetadataService interface {
 	GetTokenMetadata(ctx context.Context, token common.Address) (*entity.TokenMetadata, error)
 }
+
+type AuthService interface {
+	Nonce(ctx context.Context, owner common.Address) (string, error)
+	Verify(ctx context.Context, message string, signature string) (*entity.Session, error)
+	Authenticate(ctx context.Context, token string) (common.Address, error)
+}
+
+type FrameVerifier interface {
+	VerifyFrameAction(ctx context.Context, payload *entity.FramePayload) (*entity.FrameAction, error)
+}
+
+type TokenImageService interface {
+	RenderTokenImage(ctx context.Context, request *entity.TokenImageRequest) (*entity.RenderedImage, error)
+}
+
+type WalletTradeService interface {
+	BuildSwapTransaction(
+		ctx context.Context,
+		id string,
+		owner common.Address,
+		wallet common.Address,
+		tokenAddress common.Address,
+		ethIn string,
+	) (*entity.FrameTransaction, error)
+	ConfirmSwapTransaction(ctx context.Context, owner common.Address, id string, hash common.Hash) error
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_paramOrderID = "orderID"
+)
+
+type placeOrderRequest struct {
+	Token      string `json:"token"`
+	EthIn      string `json:"ethIn"`
+	LimitPrice string `json:"limitPrice"`
+}
+
+func (h *Handler) MakePlaceOrderHandler(svc OrderService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		request := &placeOrderRequest{}
+		if err := c.Bind(request); err != nil || !common.IsHexAddress(request.Token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "token, ethIn and limitPrice are required",
+			})
+		}
+
+		order, err := svc.PlaceLimitOrder(
+			c.Request().Context(),
+			common.HexToAddress(owner),
+			common.HexToAddress(request.Token),
+			request.EthIn,
+			request.LimitPrice,
+		)
+
+		return orderResponse(c, order, err)
+	}
+}
+
+func (h *Handler) MakeListOrdersHandler(svc OrderService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		orders, err := svc.Orders(c.Request().Context(), common.HexToAddress(owner))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"orders": orders,
+			},
+		})
+	}
+}
+
+func (h *Handler) MakeGetOrderHandler(svc OrderService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		order, err := svc.Order(c.Request().Context(), common.HexToAddress(owner), c.Param(_paramOrderID))
+		return orderResponse(c, order, err)
+	}
+}
+
+func (h *Handler) MakeCancelOrderHandler(svc OrderService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		order, err := svc.CancelOrder(c.Request().Context(), common.HexToAddress(owner), c.Param(_paramOrderID))
+		return orderResponse(c, order, err)
+	}
+}
+
+func orderResponse(c echo.Context, order *entity.LimitOrder, err error) error {
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrInvalidSellAmount), errors.Is(err, entity.ErrInvalidLimitPrice):
+		return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrNoOrderFound):
+		return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrOrderNotOpen):
+		return server.ResponseJSON(c, http.StatusConflict, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case err != nil:
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"order": order,
+		},
+	})
+}
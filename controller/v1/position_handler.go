@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_paramPositionID = "positionID"
+)
+
+type positionThresholdsRequest struct {
+	StopLossBps   int `json:"stopLossBps"`
+	TakeProfitBps int `json:"takeProfitBps"`
+}
+
+func (h *Handler) MakeListPositionsHandler(svc PositionService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		positions, err := svc.Positions(c.Request().Context(), common.HexToAddress(owner))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"positions": positions,
+			},
+		})
+	}
+}
+
+func (h *Handler) MakeGetPositionHandler(svc PositionService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		position, err := svc.Position(c.Request().Context(), common.HexToAddress(owner), c.Param(_paramPositionID))
+		return positionResponse(c, position, err)
+	}
+}
+
+func (h *Handler) MakeSetPositionThresholdsHandler(svc PositionService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		request := &positionThresholdsRequest{}
+		if err := c.Bind(request); err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid thresholds",
+			})
+		}
+
+		position, err := svc.SetThresholds(
+			c.Request().Context(),
+			common.HexToAddress(owner),
+			c.Param(_paramPositionID),
+			request.StopLossBps,
+			request.TakeProfitBps,
+		)
+
+		return positionResponse(c, position, err)
+	}
+}
+
+func positionResponse(c echo.Context, position *entity.Position, err error) error {
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrInvalidPositionLimits):
+		return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrNoPositionFound):
+		return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrPositionNotOpen), errors.Is(err, entity.ErrPositionNotHeld):
+		return server.ResponseJSON(c, http.StatusConflict, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case err != nil:
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"position": position,
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+type setPreferencesRequest struct {
+	HoldingMode string `json:"holdingMode"`
+	Recipient   string `json:"recipient"`
+}
+
+func (h *Handler) MakeGetPreferencesHandler(svc PreferencesService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		preferences, err := svc.Preferences(c.Request().Context(), common.HexToAddress(owner))
+		return preferencesResponse(c, preferences, err)
+	}
+}
+
+func (h *Handler) MakeSetPreferencesHandler(svc PreferencesService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		request := &setPreferencesRequest{}
+		if err := c.Bind(request); err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "holdingMode is required",
+			})
+		}
+
+		preferences, err := svc.SetHoldingMode(
+			c.Request().Context(),
+			common.HexToAddress(owner),
+			request.HoldingMode,
+			request.Recipient,
+		)
+
+		return preferencesResponse(c, preferences, err)
+	}
+}
+
+func preferencesResponse(c echo.Context, preferences *entity.Preferences, err error) error {
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrInvalidPreferences):
+		return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case err != nil:
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"preferences": preferences,
+		},
+	})
+}
@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_queryQuoteSell   = "sell"
+	_queryQuoteBuy    = "buy"
+	_queryQuoteAmount = "amount"
+
+	_queryHold          = "hold"
+	_queryStopLossBps   = "stopLossBps"
+	_queryTakeProfitBps = "takeProfitBps"
+
+	_nativeSymbol = "eth"
+)
+
+func (h *Handler) MakeQuoteHandler(svc QuoteService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.QueryParam(_queryOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		sellToken, err := quoteToken(c.QueryParam(_queryQuoteSell))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid sell token",
+			})
+		}
+
+		buyToken, err := quoteToken(c.QueryParam(_queryQuoteBuy))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid buy token",
+			})
+		}
+
+		controls, err := tradeControls(c)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		hold, thresholds, err := positionTerms(c)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		preview, err := svc.Preview(
+			c.Request().Context(),
+			common.HexToAddress(owner),
+			sellToken,
+			buyToken,
+			c.QueryParam(_queryQuoteAmount),
+			controls,
+			hold,
+			thresholds,
+		)
+		switch {
+		case err == nil:
+		case errors.Is(err, entity.ErrInvalidSellAmount),
+			errors.Is(err, entity.ErrInvalidSwapPair),
+			errors.Is(err, entity.ErrInvalidTradeControls),
+			errors.Is(err, entity.ErrInvalidPositionLimits):
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": err.Error(),
+			})
+		case errors.Is(err, entity.ErrNoQuoteFound):
+			return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
+				"error": err.Error(),
+			})
+		case err != nil:
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"quote": preview,
+			},
+		})
+	}
+}
+
+// quoteToken accepts a token address or "eth" for the native token.
+func quoteToken(raw string) (common.Address, error) {
+	switch {
+	case strings.EqualFold(raw, _nativeSymbol):
+		return entity.NativeToken, nil
+	case common.IsHexAddress(raw):
+		return common.HexToAddress(raw), nil
+	default:
+		return common.Address{}, entity.ErrInvalidQuoteToken
+	}
+}
+
+// positionTerms reads the optional hold flag and position thresholds the
+// previewed trade is signed with.
+func positionTerms(c echo.Context) (bool, entity.PositionThresholds, error) {
+	thresholds := entity.PositionThresholds{}
+	hold := false
+	if raw := c.QueryParam(_queryHold); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return false, thresholds, fmt.Errorf("invalid %s", _queryHold)
+		}
+
+		hold = parsed
+	}
+
+	for param, target := range map[string]*int{
+		_queryStopLossBps:   &thresholds.StopLossBps,
+		_queryTakeProfitBps: &thresholds.TakeProfitBps,
+	} {
+		raw := c.QueryParam(param)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return false, thresholds, fmt.Errorf("invalid %s", param)
+		}
+
+		*target = value
+	}
+
+	return hold, thresholds, nil
+}
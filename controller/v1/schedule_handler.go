@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_paramScheduleID = "scheduleID"
+)
+
+type createScheduleRequest struct {
+	Token           string `json:"token"`
+	EthIn           string `json:"ethIn"`
+	Interval        string `json:"interval"`
+	Runs            int    `json:"runs"`
+	MissedRunPolicy string `json:"missedRunPolicy"`
+}
+
+func (h *Handler) MakeCreateScheduleHandler(svc ScheduleService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		request := &createScheduleRequest{}
+		if err := c.Bind(request); err != nil || !common.IsHexAddress(request.Token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "token, ethIn, interval and runs are required",
+			})
+		}
+
+		interval, err := time.ParseDuration(request.Interval)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid interval",
+			})
+		}
+
+		schedule, err := svc.CreateSchedule(
+			c.Request().Context(),
+			common.HexToAddress(owner),
+			common.HexToAddress(request.Token),
+			request.EthIn,
+			interval,
+			request.Runs,
+			request.MissedRunPolicy,
+		)
+
+		return scheduleResponse(c, schedule, err)
+	}
+}
+
+func (h *Handler) MakeListSchedulesHandler(svc ScheduleService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		schedules, err := svc.Schedules(c.Request().Context(), common.HexToAddress(owner))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{
+				"schedules": schedules,
+			},
+		})
+	}
+}
+
+func (h *Handler) MakeGetScheduleHandler(svc ScheduleService) echo.HandlerFunc {
+	return h.makeScheduleActionHandler(svc.Schedule)
+}
+
+func (h *Handler) MakePauseScheduleHandler(svc ScheduleService) echo.HandlerFunc {
+	return h.makeScheduleActionHandler(svc.PauseSchedule)
+}
+
+func (h *Handler) MakeResumeScheduleHandler(svc ScheduleService) echo.HandlerFunc {
+	return h.makeScheduleActionHandler(svc.ResumeSchedule)
+}
+
+func (h *Handler) MakeCancelScheduleHandler(svc ScheduleService) echo.HandlerFunc {
+	return h.makeScheduleActionHandler(svc.CancelSchedule)
+}
+
+func (h *Handler) makeScheduleActionHandler(
+	action func(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error),
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		schedule, err := action(c.Request().Context(), common.HexToAddress(owner), c.Param(_paramScheduleID))
+		return scheduleResponse(c, schedule, err)
+	}
+}
+
+func scheduleResponse(c echo.Context, schedule *entity.Schedule, err error) error {
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrInvalidSellAmount), errors.Is(err, entity.ErrInvalidSchedule):
+		return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrNoScheduleFound):
+		return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrScheduleNotActive),
+		errors.Is(err, entity.ErrScheduleNotPaused),
+		errors.Is(err, entity.ErrScheduleNotRunning),
+		errors.Is(err, entity.ErrLockNotAcquired):
+		return server.ResponseJSON(c, http.StatusConflict, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case err != nil:
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"schedule": schedule,
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_paramSweepID = "sweepID"
+)
+
+func (h *Handler) MakeStartSweepHandler(svc SweepService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		sweep, err := svc.StartSweep(c.Request().Context(), common.HexToAddress(owner))
+		return sweepResponse(c, sweep, err)
+	}
+}
+
+func (h *Handler) MakeGetSweepHandler(svc SweepService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		owner := c.Param(_paramOwner)
+		if !common.IsHexAddress(owner) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid owner address",
+			})
+		}
+
+		sweep, err := svc.Sweep(c.Request().Context(), common.HexToAddress(owner), c.Param(_paramSweepID))
+		return sweepResponse(c, sweep, err)
+	}
+}
+
+func sweepResponse(c echo.Context, sweep *entity.Sweep, err error) error {
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrNoSweepFound):
+		return server.ResponseJSON(c, http.StatusNotFound, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case errors.Is(err, entity.ErrSweepInProgress):
+		return server.ResponseJSON(c, http.StatusConflict, map[string]interface{}{
+			"error": err.Error(),
+		})
+	case err != nil:
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseJSON(c, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"sweep": sweep,
+		},
+	})
+}
@@ -0,0 +1,521 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/labstack/echo/v4"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/server"
+)
+
+const (
+	_maxFramePresets    = 3
+	_minImageSecretSize = 32
+	_frameTradeIDLength = 16
+
+	_queryEthAmount = "eth"
+)
+
+var (
+	//go:embed templates/frame.html
+	_templates embed.FS
+
+	_frameTemplate = template.Must(template.New("frame.html").Funcs(template.FuncMap{
+		"inc": func(i int) int { return i + 1 },
+	}).ParseFS(_templates, "templates/frame.html"))
+
+	_weiPerEth = new(big.Rat).SetInt64(1e18)
+
+	errInvalidEthAmount   = errors.New("invalid ETH amount")
+	errUnknownFrameButton = errors.New("unknown button")
+)
+
+type FrameConfig struct {
+	PublicURL     string
+	ExplorerURL   string
+	PresetAmounts []string
+	// NonCustodial makes buy buttons return transactions for the user's own
+	// wallet instead of trading from a custodial trading account.
+	NonCustodial bool
+	// ImageSecret signs owner image urls, the public image route only draws
+	// an owner's balance and trades for urls of frames served to them.
+	ImageSecret string
+}
+
+func (cfg FrameConfig) Validate() error {
+	if cfg.PublicURL == "" {
+		return errors.New("frames require a public url")
+	}
+
+	if len(cfg.ImageSecret) < _minImageSecretSize {
+		return fmt.Errorf("frames require an image secret of at least %d characters", _minImageSecretSize)
+	}
+
+	if len(cfg.PresetAmounts) > _maxFramePresets {
+		return fmt.Errorf("at most %d preset amounts are supported", _maxFramePresets)
+	}
+
+	for _, amount := range cfg.PresetAmounts {
+		if _, err := parseEthAmount(amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cfg FrameConfig) url(format string, args ...any) string {
+	return strings.TrimSuffix(cfg.PublicURL, "/") + fmt.Sprintf(format, args...)
+}
+
+// imageURL points at the rendered token card, owner specific cards are
+// signed for the owner the frame is served to.
+func (cfg FrameConfig) imageURL(token common.Address, owner *common.Address) string {
+	url := cfg.url("/frames/images/token/%s", token.Hex())
+	if owner == nil {
+		return url
+	}
+
+	return fmt.Sprintf("%s?%s=%s&%s=%s", url, _queryOwner, owner.Hex(), _querySignature, cfg.ownerImageSignature(token, *owner))
+}
+
+// ownerImageSignature authorises drawing owner's state on token's card.
+func (cfg FrameConfig) ownerImageSignature(token common.Address, owner common.Address) string {
+	mac := hmac.New(sha256.New, []byte(cfg.ImageSecret))
+	mac.Write([]byte("frame-image:"))
+	mac.Write(token.Bytes())
+	mac.Write(owner.Bytes())
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *Handler) MakeTokenFrameHandler(svc TokenMetadataService, cfg FrameConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Param(_paramTokenAddress)
+		if !common.IsHexAddress(token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid token address",
+			})
+		}
+
+		metadata, err := svc.GetTokenMetadata(c.Request().Context(), common.HexToAddress(token))
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		frame, err := buyFrame(cfg, common.HexToAddress(token), metadata)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return renderFrame(c, frame)
+	}
+}
+
+func (h *Handler) MakeTokenFrameBuyHandler(
+	accountSvc AccountService,
+	metadataSvc TokenMetadataService,
+	cfg FrameConfig,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		action := frameAction(c)
+		token := c.Param(_paramTokenAddress)
+		if !common.IsHexAddress(token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid token address",
+			})
+		}
+
+		if signed, ok := signedFrameToken(action); !ok || signed != common.HexToAddress(token) {
+			return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+				"error": "frame action signed for another token",
+			})
+		}
+
+		tokenAddress := common.HexToAddress(token)
+		metadata, err := metadataSvc.GetTokenMetadata(c.Request().Context(), tokenAddress)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		owner := common.HexToAddress(action.Owner)
+		amount, err := frameBuyAmount(cfg, action)
+		if err != nil {
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, frameErrorReason(err)))
+		}
+
+		err = accountSvc.PlaceTradeRequest(c.Request().Context(), owner, tokenAddress, amount)
+		if err != nil {
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, frameErrorReason(err)))
+		}
+
+		return renderFrame(c, pendingFrame(cfg, tokenAddress, owner, metadata))
+	}
+}
+
+func (h *Handler) MakeTokenFrameStatusHandler(
+	accountSvc AccountService,
+	metadataSvc TokenMetadataService,
+	cfg FrameConfig,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		action := frameAction(c)
+		token := c.Param(_paramTokenAddress)
+		if !common.IsHexAddress(token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid token address",
+			})
+		}
+
+		if signed, ok := signedFrameToken(action); !ok || signed != common.HexToAddress(token) {
+			return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+				"error": "frame action signed for another token",
+			})
+		}
+
+		tokenAddress := common.HexToAddress(token)
+		metadata, err := metadataSvc.GetTokenMetadata(c.Request().Context(), tokenAddress)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		owner := common.HexToAddress(action.Owner)
+		trade, err := accountSvc.LatestTrade(c.Request().Context(), owner)
+		switch {
+		case err == nil:
+		case errors.Is(err, entity.ErrNoTradesFound):
+			frame, err := buyFrame(cfg, tokenAddress, metadata)
+			if err != nil {
+				return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, frameErrorReason(err)))
+			}
+
+			return renderFrame(c, frame)
+		default:
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, frameErrorReason(err)))
+		}
+
+		// the latest trade belongs to another token until this buy is
+		// picked up by the processor.
+		if !common.IsHexAddress(trade.Request.BuyToken) || common.HexToAddress(trade.Request.BuyToken) != tokenAddress {
+			return renderFrame(c, pendingFrame(cfg, tokenAddress, owner, metadata))
+		}
+
+		switch trade.Status() {
+		case entity.TradeStatusSuccess:
+			return renderFrame(c, successFrame(cfg, tokenAddress, owner, metadata, trade))
+		case entity.TradeStatusFailed:
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, "trade failed"))
+		case entity.TradeStatusCancelled:
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, "trade cancelled"))
+		default:
+			return renderFrame(c, pendingFrame(cfg, tokenAddress, owner, metadata))
+		}
+	}
+}
+
+func (h *Handler) MakeTokenFrameTxHandler(svc WalletTradeService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		action := frameAction(c)
+		token := c.Param(_paramTokenAddress)
+		if !common.IsHexAddress(token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid token address",
+			})
+		}
+
+		if signed, ok := signedFrameToken(action); !ok || signed != common.HexToAddress(token) {
+			return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+				"error": "frame action signed for another token",
+			})
+		}
+
+		amount := c.QueryParam(_queryEthAmount)
+		if amount == "" {
+			amount = strings.TrimSpace(action.InputText)
+		}
+
+		ethIn, err := parseEthAmount(amount)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		if action.State == "" {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "missing frame state",
+			})
+		}
+
+		owner := common.HexToAddress(action.Owner)
+		wallet := owner
+		if common.IsHexAddress(action.Address) {
+			wallet = common.HexToAddress(action.Address)
+		}
+
+		transaction, err := svc.BuildSwapTransaction(
+			c.Request().Context(),
+			action.State,
+			owner,
+			wallet,
+			common.HexToAddress(token),
+			ethIn,
+		)
+		switch {
+		case err == nil:
+		case errors.Is(err, entity.ErrTradeNotPending):
+			return server.ResponseJSON(c, http.StatusConflict, map[string]interface{}{
+				"error": err.Error(),
+			})
+		default:
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		return server.ResponseJSON(c, http.StatusOK, transaction)
+	}
+}
+
+func (h *Handler) MakeTokenFrameTxCallbackHandler(
+	walletSvc WalletTradeService,
+	metadataSvc TokenMetadataService,
+	cfg FrameConfig,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		action := frameAction(c)
+		token := c.Param(_paramTokenAddress)
+		if !common.IsHexAddress(token) {
+			return server.ResponseJSON(c, http.StatusBadRequest, map[string]interface{}{
+				"error": "invalid token address",
+			})
+		}
+
+		if signed, ok := signedFrameToken(action); !ok || signed != common.HexToAddress(token) {
+			return server.ResponseJSON(c, http.StatusUnauthorized, map[string]interface{}{
+				"error": "frame action signed for another token",
+			})
+		}
+
+		tokenAddress := common.HexToAddress(token)
+		metadata, err := metadataSvc.GetTokenMetadata(c.Request().Context(), tokenAddress)
+		if err != nil {
+			return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		owner := common.HexToAddress(action.Owner)
+		hash, err := hexutil.Decode(action.TransactionID)
+		if err != nil || len(hash) != common.HashLength {
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, "missing transaction"))
+		}
+
+		if action.State == "" {
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, "missing frame state"))
+		}
+
+		err = walletSvc.ConfirmSwapTransaction(c.Request().Context(), owner, action.State, common.BytesToHash(hash))
+		if err != nil {
+			return renderFrame(c, errorFrame(cfg, tokenAddress, owner, metadata, frameErrorReason(err)))
+		}
+
+		return renderFrame(c, pendingFrame(cfg, tokenAddress, owner, metadata))
+	}
+}
+
+func buyFrame(cfg FrameConfig, token common.Address, metadata *entity.TokenMetadata) (*entity.Frame, error) {
+	frame := &entity.Frame{
+		Title:       fmt.Sprintf("Buy %s at $%s", metadata.Ticker, metadata.Price),
+		Image:       cfg.imageURL(token, nil),
+		AspectRatio: entity.FrameAspectRatioSquare,
+		PostURL:     cfg.url("/frames/token/%s/buy", token.Hex()),
+		InputText:   "Custom amount in ETH",
+	}
+
+	if cfg.NonCustodial {
+		// the state names the wallet trade the frame's transaction is kept
+		// under, it comes back signed with the tx request and its callback.
+		id := make([]byte, _frameTradeIDLength)
+		if _, err := rand.Read(id); err != nil {
+			return nil, err
+		}
+
+		frame.State = hex.EncodeToString(id)
+		txURL := cfg.url("/frames/token/%s/tx", token.Hex())
+		callbackURL := cfg.url("/frames/token/%s/tx-callback", token.Hex())
+		for _, amount := range cfg.PresetAmounts {
+			frame.Buttons = append(frame.Buttons, entity.FrameButton{
+				Label:   fmt.Sprintf("Ξ %s", amount),
+				Action:  entity.FrameButtonTx,
+				Target:  fmt.Sprintf("%s?%s=%s", txURL, _queryEthAmount, amount),
+				PostURL: callbackURL,
+			})
+		}
+
+		frame.Buttons = append(frame.Buttons, entity.FrameButton{
+			Label:   "Buy custom",
+			Action:  entity.FrameButtonTx,
+			Target:  txURL,
+			PostURL: callbackURL,
+		})
+
+		return frame, nil
+	}
+
+	for _, amount := range cfg.PresetAmounts {
+		frame.Buttons = append(frame.Buttons, entity.FrameButton{
+			Label:  fmt.Sprintf("Ξ %s", amount),
+			Action: entity.FrameButtonPost,
+		})
+	}
+
+	frame.Buttons = append(frame.Buttons, entity.FrameButton{
+		Label:  "Buy custom",
+		Action: entity.FrameButtonPost,
+	})
+
+	return frame, nil
+}
+
+func pendingFrame(
+	cfg FrameConfig,
+	token common.Address,
+	owner common.Address,
+	metadata *entity.TokenMetadata,
+) *entity.Frame {
+	return &entity.Frame{
+		Title:       fmt.Sprintf("Buying %s", metadata.Ticker),
+		Image:       cfg.imageURL(token, &owner),
+		AspectRatio: entity.FrameAspectRatioSquare,
+		PostURL:     cfg.url("/frames/token/%s/status", token.Hex()),
+		Buttons: []entity.FrameButton{
+			{Label: "Refresh status", Action: entity.FrameButtonPost},
+		},
+	}
+}
+
+func successFrame(
+	cfg FrameConfig,
+	token common.Address,
+	owner common.Address,
+	metadata *entity.TokenMetadata,
+	trade *entity.Trade,
+) *entity.Frame {
+	frame := &entity.Frame{
+		Title:       fmt.Sprintf("Bought %s", metadata.Ticker),
+		Image:       cfg.imageURL(token, &owner),
+		AspectRatio: entity.FrameAspectRatioSquare,
+		PostURL:     cfg.url("/frames/token/%s", token.Hex()),
+		Buttons: []entity.FrameButton{
+			{Label: "Buy more", Action: entity.FrameButtonPost},
+		},
+	}
+
+	if cfg.ExplorerURL != "" {
+		frame.Buttons = append(frame.Buttons, entity.FrameButton{
+			Label:  "View transaction",
+			Action: entity.FrameButtonLink,
+			Target: fmt.Sprintf("%s/tx/%s", strings.TrimSuffix(cfg.ExplorerURL, "/"), trade.TxnHash),
+		})
+	}
+
+	return frame
+}
+
+func errorFrame(
+	cfg FrameConfig,
+	token common.Address,
+	owner common.Address,
+	metadata *entity.TokenMetadata,
+	reason string,
+) *entity.Frame {
+	return &entity.Frame{
+		Title:       fmt.Sprintf("Failed to buy %s: %s", metadata.Ticker, reason),
+		Image:       cfg.imageURL(token, &owner),
+		AspectRatio: entity.FrameAspectRatioSquare,
+		PostURL:     cfg.url("/frames/token/%s", token.Hex()),
+		Buttons: []entity.FrameButton{
+			{Label: "Try again", Action: entity.FrameButtonPost},
+		},
+	}
+}
+
+// frameBuyAmount maps the pressed button to a preset amount, the button after
+// the presets buys the amount typed into the input.
+func frameBuyAmount(cfg FrameConfig, action *entity.FrameAction) (string, error) {
+	index := int(action.ButtonIndex)
+	switch {
+	case index >= 1 && index <= len(cfg.PresetAmounts):
+		return parseEthAmount(cfg.PresetAmounts[index-1])
+	case index == len(cfg.PresetAmounts)+1:
+		return parseEthAmount(strings.TrimSpace(action.InputText))
+	default:
+		return "", fmt.Errorf("%w %d", errUnknownFrameButton, index)
+	}
+}
+
+// frameErrorReason maps err to the reason shown in a frame title, errors
+// the user can act on are named and the rest reported generically.
+func frameErrorReason(err error) string {
+	switch {
+	case errors.Is(err, errInvalidEthAmount):
+		return "enter a valid ETH amount"
+	case errors.Is(err, errUnknownFrameButton):
+		return "unknown button"
+	case errors.Is(err, entity.ErrInvalidTradeControls):
+		return "trade limits not met"
+	case errors.Is(err, entity.ErrNoQuoteFound):
+		return "no quote available"
+	case errors.Is(err, entity.ErrNoTradesFound):
+		return "no swap awaiting this transaction"
+	case errors.Is(err, entity.ErrTransactionMismatch):
+		return "transaction does not match the quoted swap"
+	case errors.Is(err, entity.ErrTradeNotPending):
+		return "swap already sent"
+	default:
+		return "something went wrong, try again"
+	}
+}
+
+// parseEthAmount converts a decimal ETH amount to wei.
+func parseEthAmount(amount string) (string, error) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok || value.Sign() <= 0 {
+		return "", fmt.Errorf("%w %q", errInvalidEthAmount, amount)
+	}
+
+	wei := new(big.Rat).Mul(value, _weiPerEth)
+	return new(big.Int).Quo(wei.Num(), wei.Denom()).String(), nil
+}
+
+func renderFrame(c echo.Context, frame *entity.Frame) error {
+	rendered := &bytes.Buffer{}
+	if err := _frameTemplate.Execute(rendered, frame); err != nil {
+		return server.ResponseJSON(c, http.StatusInternalServerError, map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return server.ResponseHTML(c, http.StatusOK, rendered.String())
+}
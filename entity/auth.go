@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Session struct {
+	Owner     string    `json:"owner"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// KeySIWENonce is keyed by the nonce itself, so requesting a new nonce never
+// invalidates the ones already handed out for the owner.
+func KeySIWENonce(owner common.Address, nonce string) string {
+	return fmt.Sprintf("SIWE_NONCE:%s:%s", owner.Hex(), nonce)
+}
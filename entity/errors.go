@@ -9,4 +9,56 @@ var (
 	ErrNoTradesFound  = errors.New("no trades found")
 
 	ErrNoQuoteFound = errors.New("no quote found")
+
+	ErrInvalidSellAmount = errors.New("invalid sell amount")
+	ErrNothingToSell     = errors.New("nothing to sell")
+	ErrInvalidWithdrawal = errors.New("invalid withdraw amount")
+	ErrNothingToWithdraw = errors.New("balance does not cover the withdrawal")
+	ErrInvalidSwapPair   = errors.New("sell and buy token must differ")
+	ErrInvalidQuoteToken = errors.New("invalid quote token")
+
+	ErrNoQuoteTerms          = errors.New("quote not found or already used")
+	ErrQuoteExpired          = errors.New("quote expired")
+	ErrQuoteBelowMinimum     = errors.New("fresh quote below confirmed minimum")
+	ErrInvalidQuoteSignature = errors.New("invalid quote signature")
+
+	ErrNoOrderFound      = errors.New("no order found")
+	ErrOrderNotOpen      = errors.New("order is not open")
+	ErrInvalidLimitPrice = errors.New("invalid limit price")
+
+	ErrNoPositionFound       = errors.New("no position found")
+	ErrPositionNotHeld       = errors.New("position tokens are not held by the trading account")
+	ErrPositionNotOpen       = errors.New("position is not open")
+	ErrInvalidPositionLimits = errors.New("invalid position thresholds")
+
+	ErrNoScheduleFound    = errors.New("no schedule found")
+	ErrInvalidSchedule    = errors.New("invalid schedule")
+	ErrScheduleNotActive  = errors.New("schedule is not active")
+	ErrScheduleNotPaused  = errors.New("schedule is not paused")
+	ErrScheduleNotRunning = errors.New("schedule already ended")
+
+	ErrNoPendingTrade  = errors.New("no pending trade found")
+	ErrTradeNotPending = errors.New("trade is no longer pending")
+
+	ErrNoSweepFound    = errors.New("no sweep found")
+	ErrSweepInProgress = errors.New("a sweep is already in progress")
+
+	ErrNoPreferencesFound = errors.New("no preferences found")
+	ErrInvalidPreferences = errors.New("invalid preferences")
+
+	ErrNoFollowFound      = errors.New("no follow found")
+	ErrInvalidFollowTerms = errors.New("invalid follow terms")
+
+	ErrInvalidTradeControls = errors.New("invalid trade controls")
+	ErrSlippageTooHigh      = errors.New("quote slippage above tolerance")
+	ErrPriceImpactTooHigh   = errors.New("quote price impact above tolerance")
+
+	ErrLockNotAcquired = errors.New("lock not acquired")
+
+	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrTransactionMismatch = errors.New("transaction does not match the quoted swap")
+
+	ErrInvalidFrameAction = errors.New("invalid frame action")
+	ErrNoFrameOwner       = errors.New("fid has no verified address")
 )
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	FollowOutcomeSubmitted = "submitted"
+	FollowOutcomeSkipped   = "skipped"
+	FollowOutcomeFailed    = "failed"
+)
+
+// FollowTerms size a follower's copy of each leader buy, either a fixed EthIn
+// or RatioBps of the leader's amount, bounded by the optional caps. The
+// follower's own slippage and price impact limits apply to every copy.
+type FollowTerms struct {
+	EthIn             string `json:"ethIn,omitempty"`
+	RatioBps          int    `json:"ratioBps,omitempty"`
+	MaxEthPerTrade    string `json:"maxEthPerTrade,omitempty"`
+	MaxEthPerDay      string `json:"maxEthPerDay,omitempty"`
+	SlippageBps       int    `json:"slippageBps,omitempty"`
+	MaxPriceImpactBps int    `json:"maxPriceImpactBps,omitempty"`
+}
+
+type Follow struct {
+	Leader   string `json:"leader"`
+	Follower string `json:"follower"`
+	FollowTerms
+	// SpentDay is the UTC day SpentToday was accumulated on.
+	SpentDay   string          `json:"spentDay,omitempty"`
+	SpentToday string          `json:"spentToday,omitempty"`
+	Outcomes   []FollowOutcome `json:"outcomes"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	UpdatedAt  time.Time       `json:"updatedAt"`
+}
+
+// FollowOutcome is what one leader trade produced for the follower, TradeID
+// resolves the copied trade through KeyTrade.
+type FollowOutcome struct {
+	LeaderTxn string    `json:"leaderTxn"`
+	Token     string    `json:"token"`
+	EthIn     string    `json:"ethIn,omitempty"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	TradeID   string    `json:"tradeId,omitempty"`
+	Trade     *Trade    `json:"trade,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+func KeyFollow(leader common.Address, follower common.Address) string {
+	return fmt.Sprintf("FOLLOW:%s:%s", leader.Hex(), follower.Hex())
+}
+
+func KeyFollowerPattern(leader common.Address) string {
+	return fmt.Sprintf("FOLLOW:%s:*", leader.Hex())
+}
+
+func KeyFollowingPattern(follower common.Address) string {
+	return fmt.Sprintf("FOLLOW:*:%s", follower.Hex())
+}
+
+func KeyFollowLock(leader common.Address, follower common.Address) string {
+	return fmt.Sprintf("FOLLOW_LOCK:%s:%s", leader.Hex(), follower.Hex())
+}
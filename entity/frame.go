@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type FrameCastID struct {
+	Fid  uint64 `json:"fid"`
+	Hash string `json:"hash"`
+}
+
+type FrameUntrustedData struct {
+	Fid           uint64      `json:"fid"`
+	URL           string      `json:"url"`
+	MessageHash   string      `json:"messageHash"`
+	Timestamp     int64       `json:"timestamp"`
+	Network       int         `json:"network"`
+	ButtonIndex   uint32      `json:"buttonIndex"`
+	InputText     string      `json:"inputText"`
+	State         string      `json:"state"`
+	TransactionID string      `json:"transactionId"`
+	Address       string      `json:"address"`
+	CastID        FrameCastID `json:"castId"`
+}
+
+type FrameTrustedData struct {
+	MessageBytes string `json:"messageBytes"`
+}
+
+// FramePayload is the body Farcaster clients POST to a frame, only
+// TrustedData is signed.
+type FramePayload struct {
+	UntrustedData FrameUntrustedData `json:"untrustedData"`
+	TrustedData   FrameTrustedData   `json:"trustedData"`
+}
+
+// FrameAction is a verified frame interaction, Owner is the address the
+// interacting FID resolves to.
+type FrameAction struct {
+	Fid           uint64    `json:"fid"`
+	URL           string    `json:"url"`
+	ButtonIndex   uint32    `json:"buttonIndex"`
+	InputText     string    `json:"inputText"`
+	State         string    `json:"state"`
+	TransactionID string    `json:"transactionId"`
+	Address       string    `json:"address"`
+	Timestamp     time.Time `json:"timestamp"`
+	Hash          string    `json:"hash"`
+	Owner         string    `json:"owner"`
+}
+
+// KeyFrameAction marks a signed frame message as used until it expires.
+func KeyFrameAction(hash string) string {
+	return fmt.Sprintf("FRAME_ACTION:%s", hash)
+}
+
+const (
+	FrameButtonPost = "post"
+	FrameButtonLink = "link"
+	FrameButtonTx   = "tx"
+
+	FrameAspectRatioWide   = "1.91:1"
+	FrameAspectRatioSquare = "1:1"
+)
+
+type FrameButton struct {
+	Label   string
+	Action  string
+	Target  string
+	PostURL string
+}
+
+// Frame is a renderable Farcaster frame, see the fc:frame meta tags.
+type Frame struct {
+	Title       string
+	Image       string
+	AspectRatio string
+	PostURL     string
+	InputText   string
+	State       string
+	Buttons     []FrameButton
+}
+
+type FrameTransactionParams struct {
+	ABI   []any  `json:"abi"`
+	To    string `json:"to"`
+	Data  string `json:"data,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// FrameTransaction is the response to a frame tx button, the client asks the
+// user's wallet to send it with the given method.
+type FrameTransaction struct {
+	ChainID string                 `json:"chainId"`
+	Method  string                 `json:"method"`
+	Params  FrameTransactionParams `json:"params"`
+}
@@ -12,6 +12,31 @@ type Signature struct {
 	V uint8
 }
 
+type RotationReport struct {
+	Scanned  int    `json:"scanned"`
+	Rotated  int    `json:"rotated"`
+	Migrated int    `json:"migrated"`
+	Skipped  int    `json:"skipped"`
+	Failed   int    `json:"failed"`
+	Cursor   uint64 `json:"cursor"`
+}
+
+func KeyAccountPattern() string {
+	return "ACCOUNT:*"
+}
+
 func KeyAccount(account common.Address) string {
 	return fmt.Sprintf("ACCOUNT:%s", account.Hex())
 }
+
+func KeyHDIndex(owner common.Address) string {
+	return fmt.Sprintf("HD_INDEX:%s", owner.Hex())
+}
+
+func KeyHDNextIndex() string {
+	return "HD_NEXT_INDEX"
+}
+
+func KeySignerAccount(backend string, owner common.Address) string {
+	return fmt.Sprintf("SIGNER_ACCOUNT:%s:%s", backend, owner.Hex())
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type NonceState struct {
+	Signer    string    `json:"signer"`
+	Next      uint64    `json:"next"`
+	Released  []uint64  `json:"released"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func KeyNonce(signer common.Address) string {
+	return fmt.Sprintf("NONCE:%s", signer.Hex())
+}
+
+func KeyNonceLock(signer common.Address) string {
+	return fmt.Sprintf("NONCE_LOCK:%s", signer.Hex())
+}
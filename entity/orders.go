@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	OrderStatusOpen      = "open"
+	OrderStatusTriggered = "triggered"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusFailed    = "failed"
+)
+
+// LimitOrder buys Token with EthIn wei once its USD price drops to LimitPrice.
+// LimitOrder buys Token for EthIn once its price reaches LimitPrice, TradeID
+// resolves the trade a triggered order submitted through KeyTrade.
+type LimitOrder struct {
+	ID             string    `json:"id"`
+	Owner          string    `json:"owner"`
+	Token          string    `json:"token"`
+	EthIn          string    `json:"ethIn"`
+	LimitPrice     string    `json:"limitPrice"`
+	Status         string    `json:"status"`
+	TriggeredPrice string    `json:"triggeredPrice,omitempty"`
+	TradeID        string    `json:"tradeId,omitempty"`
+	Trade          *Trade    `json:"trade,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func KeyOrder(owner common.Address, id string) string {
+	return fmt.Sprintf("ORDER:%s:%s", owner.Hex(), id)
+}
+
+func KeyOwnerOrderPattern(owner common.Address) string {
+	return fmt.Sprintf("ORDER:%s:*", owner.Hex())
+}
+
+func KeyOrderPattern() string {
+	return "ORDER:*"
+}
+
+// KeyTokenOrders is the set of order keys still open for token.
+func KeyTokenOrders(token common.Address) string {
+	return fmt.Sprintf("TOKEN_ORDERS:%s", token.Hex())
+}
+
+// KeyOrderTokens is the set of tokens with open orders.
+func KeyOrderTokens() string {
+	return "ORDER_TOKENS"
+}
+
+func KeyOrderClaim(id string) string {
+	return fmt.Sprintf("ORDER_CLAIM:%s", id)
+}
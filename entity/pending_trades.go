@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	TradeTxOriginal = "original"
+	TradeTxSpeedUp  = "speed_up"
+	TradeTxCancel   = "cancel"
+)
+
+// TradeTx is one broadcast of a trade's transaction, replacements share the
+// nonce of the original so at most one of them is ever mined.
+type TradeTx struct {
+	Hash      string    `json:"hash"`
+	Kind      string    `json:"kind"`
+	GasTipCap string    `json:"gasTipCap"`
+	GasFeeCap string    `json:"gasFeeCap"`
+	SentAt    time.Time `json:"sentAt"`
+}
+
+// PendingTrade is a relayed swap or withdrawal that has not been resolved yet,
+// it keeps the signed payload so it can be re-signed with higher fees or
+// cancelled. ID is the hash of the first transaction.
+type PendingTrade struct {
+	ID string `json:"id"`
+	// Kind is the Kind of the trade record, empty for swaps.
+	Kind   string    `json:"kind,omitempty"`
+	Owner  string    `json:"owner"`
+	Signer string    `json:"signer"`
+	Nonce  uint64    `json:"nonce"`
+	To     string    `json:"to"`
+	Value  string    `json:"value"`
+	Data   string    `json:"data"`
+	Gas    uint64    `json:"gas"`
+	Txns   []TradeTx `json:"txns"`
+	// Job, Quote and the balances before the swap settle the trade once one
+	// of Txns is mined.
+	Job          TradeRequest `json:"job"`
+	Quote        Quote        `json:"quote"`
+	SellAmount   string       `json:"sellAmount"`
+	EthBefore    string       `json:"ethBefore"`
+	BoughtBefore string       `json:"boughtBefore,omitempty"`
+	CreatedAt    time.Time    `json:"createdAt"`
+}
+
+func KeyPendingTrade(owner common.Address, id string) string {
+	return fmt.Sprintf("PENDING_TRADE:%s:%s", owner.Hex(), id)
+}
+
+func KeyPendingTradePattern() string {
+	return "PENDING_TRADE:*"
+}
+
+func KeyPendingTradeLock(owner common.Address, id string) string {
+	return fmt.Sprintf("PENDING_TRADE_LOCK:%s:%s", owner.Hex(), id)
+}
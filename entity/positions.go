@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	PositionStatusOpen      = "open"
+	PositionStatusTriggered = "triggered"
+	PositionStatusFailed    = "failed"
+
+	PositionTriggerStopLoss   = "stop_loss"
+	PositionTriggerTakeProfit = "take_profit"
+)
+
+// Position is a completed buy, EthIn and Amount are what the swap actually
+// spent and received so EntryPrice, in ETH per token, reflects execution.
+// TradeID resolves the sell a triggered position submitted through KeyTrade.
+type Position struct {
+	ID         string `json:"id"`
+	Owner      string `json:"owner"`
+	Token      string `json:"token"`
+	EthIn      string `json:"ethIn"`
+	Amount     string `json:"amount"`
+	EntryPrice string `json:"entryPrice"`
+	// Held is set when the tokens stayed in the trading account, only held
+	// positions can be sold automatically.
+	Held bool `json:"held"`
+	PositionThresholds
+	Status      string    `json:"status"`
+	TriggeredBy string    `json:"triggeredBy,omitempty"`
+	TradeID     string    `json:"tradeId,omitempty"`
+	Trade       *Trade    `json:"trade,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// PositionThresholds are the loss and gain relative to a position's EthIn
+// that sell it, zero disables either.
+type PositionThresholds struct {
+	StopLossBps   int `json:"stopLossBps,omitempty"`
+	TakeProfitBps int `json:"takeProfitBps,omitempty"`
+}
+
+func KeyPosition(owner common.Address, id string) string {
+	return fmt.Sprintf("POSITION:%s:%s", owner.Hex(), id)
+}
+
+func KeyOwnerPositionPattern(owner common.Address) string {
+	return fmt.Sprintf("POSITION:%s:*", owner.Hex())
+}
+
+func KeyPositionPattern() string {
+	return "POSITION:*"
+}
+
+func KeyPositionClaim(id string) string {
+	return fmt.Sprintf("POSITION_CLAIM:%s", id)
+}
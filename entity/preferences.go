@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	HoldingModeForward   = "forward"
+	HoldingModeHold      = "hold"
+	HoldingModeRecipient = "recipient"
+)
+
+// Preferences are an owner's account settings. HoldingMode decides where the
+// tokens a trade buys go: forwarded to the owner, held in the trading account
+// or forwarded to Recipient. ETH from sells is always forwarded to the owner.
+type Preferences struct {
+	Owner       string    `json:"owner"`
+	HoldingMode string    `json:"holdingMode"`
+	Recipient   string    `json:"recipient,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func KeyPreferences(owner common.Address) string {
+	return fmt.Sprintf("PREFERENCES:%s", owner.Hex())
+}
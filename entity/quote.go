@@ -1,14 +1,106 @@
 package entity
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 var (
 	ZeroHash = common.HexToHash("")
+
+	// NativeToken stands in for ETH wherever a token address is expected.
+	NativeToken = common.HexToAddress("0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee")
+)
+
+const (
+	DefaultSlippageBps       = 100
+	DefaultMaxPriceImpactBps = 4000
 )
 
+type QuoteRequest struct {
+	SellToken  common.Address
+	BuyToken   common.Address
+	SellAmount string
+	// SlippageBps and MaxPriceImpactBps are tolerances in basis points, zero
+	// uses the defaults.
+	SlippageBps       int
+	MaxPriceImpactBps int
+	// Taker binds the quote to the wallet that will execute it, zero leaves
+	// it unbound.
+	Taker common.Address
+}
+
 type Quote struct {
+	SellToken         string `json:"sellToken,omitempty"`
+	BuyToken          string `json:"buyToken,omitempty"`
 	To                string `json:"to"`
 	Value             string `json:"value"`
 	CallData          string `json:"callData"`
 	BuyTokenToEthRate string `json:"buyTokenToEthRate"`
+	// AllowanceTarget is the spender that needs an allowance on the sold
+	// token, it is empty when selling ETH.
+	AllowanceTarget string `json:"allowanceTarget,omitempty"`
+
+	SellAmount           string        `json:"sellAmount,omitempty"`
+	BuyAmount            string        `json:"buyAmount,omitempty"`
+	Price                string        `json:"price,omitempty"`
+	GuaranteedPrice      string        `json:"guaranteedPrice,omitempty"`
+	EstimatedPriceImpact string        `json:"estimatedPriceImpact,omitempty"`
+	EstimatedGas         string        `json:"estimatedGas,omitempty"`
+	GasPrice             string        `json:"gasPrice,omitempty"`
+	Sources              []QuoteSource `json:"sources,omitempty"`
+}
+
+type QuoteSource struct {
+	Name       string `json:"name"`
+	Proportion string `json:"proportion"`
+}
+
+// QuotePreview is what a swap would yield right now, amounts are in base
+// units and fees in wei.
+type QuotePreview struct {
+	SellToken            string        `json:"sellToken"`
+	BuyToken             string        `json:"buyToken"`
+	SellAmount           string        `json:"sellAmount"`
+	BuyAmount            string        `json:"buyAmount"`
+	MinBuyAmount         string        `json:"minBuyAmount"`
+	SlippageBps          int           `json:"slippageBps"`
+	MaxPriceImpactBps    int           `json:"maxPriceImpactBps"`
+	Price                string        `json:"price"`
+	EstimatedPriceImpact string        `json:"estimatedPriceImpact"`
+	EstimatedGas         string        `json:"estimatedGas"`
+	L2Fee                string        `json:"l2Fee"`
+	L1Fee                string        `json:"l1Fee"`
+	TotalFee             string        `json:"totalFee"`
+	Sources              []QuoteSource `json:"sources"`
+	// QuoteID and QuoteHash identify the terms to confirm, QuoteHash is the
+	// EIP-712 digest the owner signs to place the trade before Deadline.
+	QuoteID       string `json:"quoteId"`
+	QuoteHash     string `json:"quoteHash"`
+	Deadline      int64  `json:"deadline"`
+	Hold          bool   `json:"hold"`
+	StopLossBps   int    `json:"stopLossBps"`
+	TakeProfitBps int    `json:"takeProfitBps"`
+}
+
+// QuoteTerms are what Owner, and only Owner, confirms by signing a quote
+// preview, the trade only executes while a fresh quote still meets
+// MinBuyAmount.
+type QuoteTerms struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	SellToken    string `json:"sellToken"`
+	BuyToken     string `json:"buyToken"`
+	SellAmount   string `json:"sellAmount"`
+	MinBuyAmount string `json:"minBuyAmount"`
+	// Hold keeps the bought tokens in the trading account, as do
+	// thresholds, which are attached to the position the trade opens.
+	Hold bool `json:"hold,omitempty"`
+	TradeControls
+	PositionThresholds
+}
+
+func KeyQuote(id string) string {
+	return fmt.Sprintf("QUOTE:%s", id)
 }
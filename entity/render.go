@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type TokenImageRequest struct {
+	Token common.Address
+	Owner *common.Address
+	Wide  bool
+}
+
+// TokenCard is everything drawn on a token image, its hash identifies the
+// rendered PNG.
+type TokenCard struct {
+	Token       string `json:"token"`
+	Ticker      string `json:"ticker"`
+	Price       string `json:"price"`
+	Logo        string `json:"logo"`
+	Balance     string `json:"balance,omitempty"`
+	TradeStatus string `json:"tradeStatus,omitempty"`
+	Wide        bool   `json:"wide"`
+}
+
+type RenderedImage struct {
+	PNG  []byte
+	ETag string
+}
+
+func KeyRender(hash string) string {
+	return fmt.Sprintf("RENDER:%s", hash)
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	ScheduleStatusActive    = "active"
+	ScheduleStatusPaused    = "paused"
+	ScheduleStatusCancelled = "cancelled"
+	ScheduleStatusCompleted = "completed"
+
+	// MissedRunSkip runs only the latest slot missed during downtime,
+	// MissedRunCatchUp runs every missed slot.
+	MissedRunSkip    = "skip"
+	MissedRunCatchUp = "catch_up"
+
+	ScheduleRunSubmitted = "submitted"
+	ScheduleRunSkipped   = "skipped"
+	ScheduleRunFailed    = "failed"
+)
+
+// Schedule buys Token with EthIn wei every IntervalSeconds until TotalRuns
+// slots have passed.
+type Schedule struct {
+	ID              string        `json:"id"`
+	Owner           string        `json:"owner"`
+	Token           string        `json:"token"`
+	EthIn           string        `json:"ethIn"`
+	IntervalSeconds int64         `json:"intervalSeconds"`
+	TotalRuns       int           `json:"totalRuns"`
+	CompletedRuns   int           `json:"completedRuns"`
+	MissedRunPolicy string        `json:"missedRunPolicy"`
+	NextRunAt       time.Time     `json:"nextRunAt"`
+	Status          string        `json:"status"`
+	Runs            []ScheduleRun `json:"runs"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+// ScheduleRun is one slot of a schedule, TradeID resolves its trade record
+// through KeyTrade.
+type ScheduleRun struct {
+	Slot    int       `json:"slot"`
+	RunAt   time.Time `json:"runAt"`
+	Status  string    `json:"status"`
+	TradeID string    `json:"tradeId,omitempty"`
+	Trade   *Trade    `json:"trade,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+func (s *Schedule) Interval() time.Duration {
+	return time.Duration(s.IntervalSeconds) * time.Second
+}
+
+func KeySchedule(owner common.Address, id string) string {
+	return fmt.Sprintf("SCHEDULE:%s:%s", owner.Hex(), id)
+}
+
+func KeyOwnerSchedulePattern(owner common.Address) string {
+	return fmt.Sprintf("SCHEDULE:%s:*", owner.Hex())
+}
+
+func KeySchedulePattern() string {
+	return "SCHEDULE:*"
+}
+
+func KeyScheduleLock(id string) string {
+	return fmt.Sprintf("SCHEDULE_LOCK:%s", id)
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	SweepStatusQueued  = "queued"
+	SweepStatusRunning = "running"
+	SweepStatusDone    = "done"
+
+	SweepResultPending = "pending"
+	SweepResultSwept   = "swept"
+	SweepResultEmpty   = "empty"
+	SweepResultHeld    = "held"
+	SweepResultFailed  = "failed"
+)
+
+// Sweep transfers every ERC-20 balance the trading account holds to
+// Recipient, the owner or their preferred recipient. Results has one entry per
+// discovered token.
+type Sweep struct {
+	ID        string        `json:"id"`
+	Owner     string        `json:"owner"`
+	Recipient string        `json:"recipient"`
+	Status    string        `json:"status"`
+	Results   []SweepResult `json:"results"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// SweepResult is the outcome for one token, tokens of open held positions
+// are left in place.
+type SweepResult struct {
+	Token   string `json:"token"`
+	Status  string `json:"status"`
+	Amount  string `json:"amount,omitempty"`
+	TxnHash string `json:"txnHash,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func KeySweep(owner common.Address, id string) string {
+	return fmt.Sprintf("SWEEP:%s:%s", owner.Hex(), id)
+}
+
+func KeySweepLock(owner common.Address) string {
+	return fmt.Sprintf("SWEEP_LOCK:%s", owner.Hex())
+}
+
+// KeyTradedToken marks token as traded by owner's trading account so sweeps
+// can discover it.
+func KeyTradedToken(owner common.Address, token common.Address) string {
+	return fmt.Sprintf("TRADED_TOKEN:%s:%s", owner.Hex(), token.Hex())
+}
+
+func KeyTradedTokenPattern(owner common.Address) string {
+	return fmt.Sprintf("TRADED_TOKEN:%s:*", owner.Hex())
+}
+
+func KeyAllTradedTokensPattern() string {
+	return "TRADED_TOKEN:*"
+}
@@ -7,20 +7,107 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// TradeRequest swaps SellToken into BuyToken from the owner's trading
+// account, either side may be NativeToken for ETH.
 type TradeRequest struct {
+	// ID is set by callers that need the trade record of this job later, see
+	// KeyTrade.
+	ID        string `json:"id,omitempty"`
+	Owner     string `json:"owner"`
+	SellToken string `json:"sellToken"`
+	BuyToken  string `json:"buyToken"`
+	// AmountIn is the amount of SellToken in base units, when empty Percent
+	// of the trading account's SellToken balance is sold instead.
+	AmountIn string `json:"amountIn,omitempty"`
+	Percent  int    `json:"percent,omitempty"`
+	// MinBuyAmount carries confirmed quote terms, the trade is refused when
+	// the fresh quote falls short.
+	MinBuyAmount string `json:"minBuyAmount,omitempty"`
+	// Hold keeps what was bought in the trading account instead of
+	// forwarding it to the owner.
+	Hold bool `json:"hold,omitempty"`
+	// CopyOf is the leader transaction a copied trade mirrors, copies are
+	// never copied again.
+	CopyOf string `json:"copyOf,omitempty"`
+	TradeControls
+	// PositionThresholds are attached to the position a buy opens, setting
+	// either keeps the bought tokens in the trading account so the position
+	// can be sold when it triggers.
+	PositionThresholds
+}
+
+// TradeControls bound the risk a single trade may take, zero values fall back
+// to the defaults. Deadline is a unix timestamp.
+type TradeControls struct {
+	SlippageBps       int   `json:"slippageBps,omitempty"`
+	MaxPriceImpactBps int   `json:"maxPriceImpactBps,omitempty"`
+	Deadline          int64 `json:"deadline,omitempty"`
+}
+
+// WithdrawRequest sends Amount wei of the trading account's ETH to the owner,
+// WithdrawMax sends the balance less the fees of the withdrawal itself.
+type WithdrawRequest struct {
+	Owner  string `json:"owner"`
+	Amount string `json:"amount"`
+}
+
+const WithdrawMax = "max"
+
+// TrackRequest follows a swap the owner broadcast from their own wallet.
+type TrackRequest struct {
+	JobID   string `json:"jobId"`
 	Owner   string `json:"owner"`
-	EthIn   string `json:"ethIn"`
-	ToToken string `json:"toToken"`
+	TxnHash string `json:"txnHash"`
+	Request Quote  `json:"request"`
 }
 
 type Trade struct {
+	// Kind is empty for swaps.
+	Kind    string    `json:"kind,omitempty"`
+	JobID   string    `json:"jobId,omitempty"`
 	Owner   string    `json:"owner"`
 	TxnHash string    `json:"txnHash"`
 	Error   string    `json:"error"`
 	Expiry  time.Time `json:"expiry"`
 	Request Quote     `json:"request"`
+	// State overrides the status derived from TxnHash and Error, it is set
+	// for trades that are known on chain before they are mined.
+	State string `json:"state,omitempty"`
+	// Txns are every broadcast of a relayed swap, replacements included.
+	Txns []TradeTx `json:"txns,omitempty"`
+	// Wallet is the owner's own wallet a non-custodial swap is quoted for.
+	Wallet string `json:"wallet,omitempty"`
+}
+
+const (
+	TradeKindWithdraw = "withdraw"
+)
+
+const (
+	TradeStatusPending   = "pending"
+	TradeStatusSubmitted = "submitted"
+	TradeStatusFailed    = "failed"
+	TradeStatusSuccess   = "success"
+	TradeStatusCancelled = "cancelled"
+)
+
+func (t *Trade) Status() string {
+	switch {
+	case t.State != "":
+		return t.State
+	case t.Error != "":
+		return TradeStatusFailed
+	case t.TxnHash != "":
+		return TradeStatusSuccess
+	default:
+		return TradeStatusPending
+	}
 }
 
 func KeyTrades(owner common.Address) string {
 	return fmt.Sprintf("TRADES:%s", owner.Hex())
 }
+
+func KeyTrade(jobID string) string {
+	return fmt.Sprintf("TRADE:%s", jobID)
+}
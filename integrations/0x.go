@@ -3,6 +3,7 @@ package integrations
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-resty/resty/v2"
@@ -28,9 +29,17 @@ type zeroXQuoteResponse struct {
 	GrossSellAmount      string `json:"grossSellAmount"`
 	SellTokenToEthRate   string `json:"sellTokenToEthRate"`
 	BuyTokenToEthRate    string `json:"buyTokenToEthRate"`
-	To                   string `json:"to"`
-	From                 string `json:"from"`
-	Data                 string `json:"data"`
+	AllowanceTarget      string `json:"allowanceTarget"`
+	GuaranteedPrice      string `json:"guaranteedPrice"`
+	EstimatedGas         string `json:"estimatedGas"`
+	GasPrice             string `json:"gasPrice"`
+	Sources              []struct {
+		Name       string `json:"name"`
+		Proportion string `json:"proportion"`
+	} `json:"sources"`
+	To   string `json:"to"`
+	From string `json:"from"`
+	Data string `json:"data"`
 }
 
 type ZeroXConfig struct {
@@ -47,13 +56,29 @@ func NewZeroXSwapper(cfg ZeroXConfig) (*ZeroXSwapper, error) {
 	return &ZeroXSwapper{client: resty.New().SetBaseURL(_zeroXURL), cfg: cfg}, nil
 }
 
-func (z *ZeroXSwapper) GetQuote(ctx context.Context, token common.Address, ethIn string) (*entity.Quote, error) {
+func (z *ZeroXSwapper) GetQuote(ctx context.Context, request *entity.QuoteRequest) (*entity.Quote, error) {
 	response := &zeroXQuoteResponse{}
 	query := map[string]string{
-		"buyToken":                        token.Hex(),
-		"sellAmount":                      ethIn,
-		"sellToken":                       "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
-		"priceImpactProtectionPercentage": "0.4",
+		"buyToken":   request.BuyToken.Hex(),
+		"sellAmount": request.SellAmount,
+		"sellToken":  request.SellToken.Hex(),
+	}
+
+	slippageBps := request.SlippageBps
+	if slippageBps == 0 {
+		slippageBps = entity.DefaultSlippageBps
+	}
+
+	maxPriceImpactBps := request.MaxPriceImpactBps
+	if maxPriceImpactBps == 0 {
+		maxPriceImpactBps = entity.DefaultMaxPriceImpactBps
+	}
+
+	query["slippagePercentage"] = bpsFraction(slippageBps)
+	query["priceImpactProtectionPercentage"] = bpsFraction(maxPriceImpactBps)
+
+	if request.Taker != (common.Address{}) {
+		query["takerAddress"] = request.Taker.Hex()
 	}
 
 	resp, err := z.client.R().SetContext(ctx).SetHeader("0x-api-key", z.cfg.ApiKey).SetHeader("0x-chain-id", z.cfg.ChainID).SetQueryParams(query).Get("/quote")
@@ -69,10 +94,40 @@ func (z *ZeroXSwapper) GetQuote(ctx context.Context, token common.Address, ethIn
 		return nil, entity.ErrNoQuoteFound
 	}
 
+	allowanceTarget := response.AllowanceTarget
+	if common.HexToAddress(allowanceTarget) == (common.Address{}) {
+		allowanceTarget = ""
+	}
+
+	sources := make([]entity.QuoteSource, 0, len(response.Sources))
+	for _, source := range response.Sources {
+		if proportion, err := strconv.ParseFloat(source.Proportion, 64); err != nil || proportion == 0 {
+			continue
+		}
+
+		sources = append(sources, entity.QuoteSource{Name: source.Name, Proportion: source.Proportion})
+	}
+
 	return &entity.Quote{
-		To:                response.To,
-		Value:             response.Value,
-		CallData:          response.Data,
-		BuyTokenToEthRate: response.BuyTokenToEthRate,
+		SellToken:            request.SellToken.Hex(),
+		BuyToken:             request.BuyToken.Hex(),
+		To:                   response.To,
+		Value:                response.Value,
+		CallData:             response.Data,
+		BuyTokenToEthRate:    response.BuyTokenToEthRate,
+		AllowanceTarget:      allowanceTarget,
+		SellAmount:           response.SellAmount,
+		BuyAmount:            response.BuyAmount,
+		Price:                response.Price,
+		GuaranteedPrice:      response.GuaranteedPrice,
+		EstimatedPriceImpact: response.EstimatedPriceImpact,
+		EstimatedGas:         response.EstimatedGas,
+		GasPrice:             response.GasPrice,
+		Sources:              sources,
 	}, nil
 }
+
+// bpsFraction formats basis points as the 0 - 1 fraction 0x expects.
+func bpsFraction(bps int) string {
+	return strconv.FormatFloat(float64(bps)/10000, 'f', -1, 64)
+}
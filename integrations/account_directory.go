@@ -0,0 +1,73 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+type ownerAccount struct {
+	Owner   string `json:"owner"`
+	Account string `json:"account"`
+}
+
+// accountDirectory maps owners to the signing accounts held by a backend that
+// keeps the keys itself, such as a keystore directory or a remote signer.
+type accountDirectory struct {
+	storage Storage
+	backend string
+}
+
+func (d *accountDirectory) get(ctx context.Context, owner common.Address) (common.Address, error) {
+	value, err := d.storage.Read(ctx, entity.KeySignerAccount(d.backend, owner))
+	if err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	response := &ownerAccount{}
+	if err = json.Unmarshal([]byte(value), response); err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	return common.HexToAddress(response.Account), nil
+}
+
+// lookup is get with entity.ErrNoAccountFound for owners without an account.
+func (d *accountDirectory) lookup(ctx context.Context, owner common.Address) (common.Address, error) {
+	account, err := d.get(ctx, owner)
+	if errors.Is(err, entity.ErrEmpty) {
+		return common.HexToAddress(""), entity.ErrNoAccountFound
+	}
+
+	return account, err
+}
+
+// putIfAbsent records account for owner unless one is already recorded, in
+// which case the existing account is returned.
+func (d *accountDirectory) putIfAbsent(
+	ctx context.Context,
+	owner common.Address,
+	account common.Address,
+) (common.Address, error) {
+	seralized, err := json.Marshal(&ownerAccount{
+		Owner:   owner.Hex(),
+		Account: account.Hex(),
+	})
+	if err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	created, err := d.storage.WriteIfAbsent(ctx, entity.KeySignerAccount(d.backend, owner), string(seralized), 0)
+	if err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	if !created {
+		return d.get(ctx, owner)
+	}
+
+	return account, nil
+}
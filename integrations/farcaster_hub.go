@@ -0,0 +1,188 @@
+package integrations
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-resty/resty/v2"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/farcaster"
+)
+
+const (
+	_hubMessageTypeFrameAction = "MESSAGE_TYPE_FRAME_ACTION"
+	_hubProtocolEthereum       = "PROTOCOL_ETHEREUM"
+)
+
+type hubValidateResponse struct {
+	Valid   bool `json:"valid"`
+	Message struct {
+		Hash string `json:"hash"`
+		Data struct {
+			Type            string `json:"type"`
+			Fid             uint64 `json:"fid"`
+			Timestamp       uint32 `json:"timestamp"`
+			FrameActionBody struct {
+				URL           string `json:"url"`
+				ButtonIndex   uint32 `json:"buttonIndex"`
+				InputText     string `json:"inputText"`
+				State         string `json:"state"`
+				TransactionID string `json:"transactionId"`
+				Address       string `json:"address"`
+			} `json:"frameActionBody"`
+		} `json:"data"`
+	} `json:"message"`
+}
+
+type hubVerificationsResponse struct {
+	Messages []struct {
+		Data struct {
+			VerificationAddAddressBody struct {
+				Address  string `json:"address"`
+				Protocol string `json:"protocol"`
+			} `json:"verificationAddAddressBody"`
+		} `json:"data"`
+	} `json:"messages"`
+}
+
+type hubIDRegistryResponse struct {
+	IDRegisterEventBody struct {
+		To string `json:"to"`
+	} `json:"idRegisterEventBody"`
+}
+
+type FarcasterHub struct {
+	client *resty.Client
+}
+
+func NewFarcasterHub(url string) *FarcasterHub {
+	return &FarcasterHub{client: resty.New().SetBaseURL(url)}
+}
+
+func (h *FarcasterHub) ValidateMessage(ctx context.Context, raw []byte) (*entity.FrameAction, error) {
+	response := &hubValidateResponse{}
+	resp, err := h.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetBody(raw).
+		Post("/v1/validateMessage")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("%w: hub responded %s", entity.ErrInvalidFrameAction, resp.Status())
+	}
+
+	if err = json.Unmarshal(resp.Body(), response); err != nil {
+		return nil, err
+	}
+
+	data := response.Message.Data
+	if !response.Valid || data.Type != _hubMessageTypeFrameAction {
+		return nil, entity.ErrInvalidFrameAction
+	}
+
+	body := data.FrameActionBody
+	return &entity.FrameAction{
+		Fid:           data.Fid,
+		URL:           string(decodeHubBytes(body.URL)),
+		ButtonIndex:   body.ButtonIndex,
+		InputText:     string(decodeHubBytes(body.InputText)),
+		State:         string(decodeHubBytes(body.State)),
+		TransactionID: encodeOptionalHex(decodeHubBytes(body.TransactionID)),
+		Address:       encodeOptionalHex(decodeHubBytes(body.Address)),
+		Timestamp:     farcaster.MessageData{Timestamp: data.Timestamp}.Time(),
+		Hash:          encodeOptionalHex(decodeHubBytes(response.Message.Hash)),
+	}, nil
+}
+
+func (h *FarcasterHub) VerifiedAddresses(ctx context.Context, fid uint64) ([]common.Address, error) {
+	response := &hubVerificationsResponse{}
+	resp, err := h.client.R().
+		SetContext(ctx).
+		SetQueryParam("fid", strconv.FormatUint(fid, 10)).
+		Get("/v1/verificationsByFid")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to fetch verifications of fid %d, hub responded %s", fid, resp.Status())
+	}
+
+	if err = json.Unmarshal(resp.Body(), response); err != nil {
+		return nil, err
+	}
+
+	addresses := make([]common.Address, 0, len(response.Messages))
+	for _, message := range response.Messages {
+		body := message.Data.VerificationAddAddressBody
+		if body.Protocol != "" && body.Protocol != _hubProtocolEthereum {
+			continue
+		}
+
+		if common.IsHexAddress(body.Address) {
+			addresses = append(addresses, common.HexToAddress(body.Address))
+		}
+	}
+
+	return addresses, nil
+}
+
+func (h *FarcasterHub) CustodyAddress(ctx context.Context, fid uint64) (common.Address, error) {
+	response := &hubIDRegistryResponse{}
+	resp, err := h.client.R().
+		SetContext(ctx).
+		SetQueryParam("fid", strconv.FormatUint(fid, 10)).
+		Get("/v1/onChainIdRegistryEventByFid")
+	if err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	if resp.IsError() {
+		return common.HexToAddress(""), fmt.Errorf("failed to fetch custody address of fid %d, hub responded %s", fid, resp.Status())
+	}
+
+	if err = json.Unmarshal(resp.Body(), response); err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	if !common.IsHexAddress(response.IDRegisterEventBody.To) {
+		return common.HexToAddress(""), entity.ErrNoFrameOwner
+	}
+
+	return common.HexToAddress(response.IDRegisterEventBody.To), nil
+}
+
+// decodeHubBytes decodes a bytes field of the hub HTTP API, which renders
+// hashes and addresses as hex and every other bytes field as base64.
+func decodeHubBytes(value string) []byte {
+	if strings.HasPrefix(value, "0x") {
+		decoded, err := hexutil.Decode(value)
+		if err == nil {
+			return decoded
+		}
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return []byte(value)
+	}
+
+	return decoded
+}
+
+func encodeOptionalHex(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	return hexutil.Encode(value)
+}
@@ -0,0 +1,153 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/farcaster"
+)
+
+// newStubHub serves each path from the given status and body, recording the
+// request body posted to /v1/validateMessage.
+func newStubHub(t *testing.T, status int, bodies map[string]string) (*FarcasterHub, *[]byte) {
+	t.Helper()
+
+	posted := new([]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			*posted, _ = io.ReadAll(r.Body)
+		}
+
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewFarcasterHub(server.URL), posted
+}
+
+func TestValidateMessage(t *testing.T) {
+	const validBody = `{
+		"valid": true,
+		"message": {
+			"hash": "0xabcd",
+			"data": {
+				"type": "MESSAGE_TYPE_FRAME_ACTION",
+				"fid": 42,
+				"timestamp": 100,
+				"frameActionBody": {
+					"url": "aHR0cHM6Ly9mcmFtZXMuZXhhbXBsZS5jb20vYnV5",
+					"buttonIndex": 2,
+					"inputText": "MC4x",
+					"state": "c3RhdGU=",
+					"address": "0x0000000000000000000000000000000000000001"
+				}
+			}
+		}
+	}`
+
+	hub, posted := newStubHub(t, http.StatusOK, map[string]string{"/v1/validateMessage": validBody})
+	action, err := hub.ValidateMessage(context.Background(), []byte{0x0a, 0x01})
+	if err != nil {
+		t.Fatalf("failed to validate: %v", err)
+	}
+
+	if string(*posted) != string([]byte{0x0a, 0x01}) {
+		t.Fatalf("expected the raw message to be posted, got %x", *posted)
+	}
+
+	switch {
+	case action.Fid != 42 || action.ButtonIndex != 2:
+		t.Fatalf("unexpected action %+v", action)
+	case action.URL != "https://frames.example.com/buy" || action.InputText != "0.1" || action.State != "state":
+		t.Fatalf("unexpected action %+v", action)
+	case action.Hash != "0xabcd" || action.Address != "0x0000000000000000000000000000000000000001":
+		t.Fatalf("unexpected action %+v", action)
+	case action.TransactionID != "":
+		t.Fatalf("unexpected transaction id %q", action.TransactionID)
+	case !action.Timestamp.Equal(farcaster.MessageData{Timestamp: 100}.Time()):
+		t.Fatalf("unexpected timestamp %s", action.Timestamp)
+	}
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "invalid", status: http.StatusOK, body: `{"valid": false}`},
+		{name: "not a frame action", status: http.StatusOK, body: `{"valid": true, "message": {"data": {"type": "MESSAGE_TYPE_CAST_ADD"}}}`},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"errCode": "unavailable"}`},
+		{name: "bad request", status: http.StatusBadRequest, body: `{"errCode": "bad_request.validation_failure"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub, _ := newStubHub(t, tt.status, map[string]string{"/v1/validateMessage": tt.body})
+			if _, err := hub.ValidateMessage(context.Background(), []byte{0x0a}); !errors.Is(err, entity.ErrInvalidFrameAction) {
+				t.Fatalf("expected an invalid frame action, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifiedAddresses(t *testing.T) {
+	hub, _ := newStubHub(t, http.StatusOK, map[string]string{"/v1/verificationsByFid": `{
+		"messages": [
+			{"data": {"verificationAddAddressBody": {"address": "0x0000000000000000000000000000000000000001", "protocol": "PROTOCOL_ETHEREUM"}}},
+			{"data": {"verificationAddAddressBody": {"address": "So11111111111111111111111111111111111111112", "protocol": "PROTOCOL_SOLANA"}}},
+			{"data": {"verificationAddAddressBody": {"address": "0x0000000000000000000000000000000000000002"}}}
+		]
+	}`})
+
+	addresses, err := hub.VerifiedAddresses(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("failed to fetch verifications: %v", err)
+	}
+
+	expected := []common.Address{
+		common.HexToAddress("0x0000000000000000000000000000000000000001"),
+		common.HexToAddress("0x0000000000000000000000000000000000000002"),
+	}
+	if len(addresses) != len(expected) || addresses[0] != expected[0] || addresses[1] != expected[1] {
+		t.Fatalf("expected %v, got %v", expected, addresses)
+	}
+
+	hub, _ = newStubHub(t, http.StatusServiceUnavailable, map[string]string{"/v1/verificationsByFid": `{}`})
+	if _, err = hub.VerifiedAddresses(context.Background(), 42); err == nil {
+		t.Fatal("expected a hub error to be returned")
+	}
+}
+
+func TestCustodyAddress(t *testing.T) {
+	hub, _ := newStubHub(t, http.StatusOK, map[string]string{
+		"/v1/onChainIdRegistryEventByFid": `{"idRegisterEventBody": {"to": "0x0000000000000000000000000000000000000003"}}`,
+	})
+
+	custody, err := hub.CustodyAddress(context.Background(), 42)
+	if err != nil || custody != common.HexToAddress("0x0000000000000000000000000000000000000003") {
+		t.Fatalf("unexpected custody address %s, %v", custody, err)
+	}
+
+	hub, _ = newStubHub(t, http.StatusOK, map[string]string{"/v1/onChainIdRegistryEventByFid": `{}`})
+	if _, err = hub.CustodyAddress(context.Background(), 42); !errors.Is(err, entity.ErrNoFrameOwner) {
+		t.Fatalf("expected no frame owner, got %v", err)
+	}
+
+	hub, _ = newStubHub(t, http.StatusBadGateway, map[string]string{"/v1/onChainIdRegistryEventByFid": `{}`})
+	if _, err = hub.CustodyAddress(context.Background(), 42); err == nil || errors.Is(err, entity.ErrNoFrameOwner) {
+		t.Fatalf("expected a hub error, got %v", err)
+	}
+}
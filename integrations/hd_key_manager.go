@@ -0,0 +1,162 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/hdwallet"
+)
+
+const (
+	// _maxInvalidChildSkips bounds the skipping of invalid child keys, which
+	// occur with a probability below 2^-127 per index.
+	_maxInvalidChildSkips = 8
+)
+
+type hdIndex struct {
+	Owner   string `json:"owner"`
+	Account string `json:"account"`
+	Index   uint32 `json:"index"`
+}
+
+// HDKeyManager derives every trading account from a single master seed along
+// m/44'/60'/0'/0/index, only the owner to index mapping is persisted so the
+// accounts can be recovered from the seed and that mapping alone.
+type HDKeyManager struct {
+	storage Storage
+	master  *hdwallet.ExtendedKey
+}
+
+func NewHDKeyManager(storage Storage, seed []byte) (*HDKeyManager, error) {
+	master, err := hdwallet.NewMaster(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HDKeyManager{storage: storage, master: master}, nil
+}
+
+func (m *HDKeyManager) derive(index uint32) (*hdwallet.ExtendedKey, error) {
+	path := make(accounts.DerivationPath, len(accounts.DefaultBaseDerivationPath), len(accounts.DefaultBaseDerivationPath)+1)
+	copy(path, accounts.DefaultBaseDerivationPath)
+
+	return m.master.Derive(append(path, index))
+}
+
+func (m *HDKeyManager) getIndex(ctx context.Context, owner common.Address) (*hdIndex, error) {
+	value, err := m.storage.Read(ctx, entity.KeyHDIndex(owner))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &hdIndex{}
+	if err = json.Unmarshal([]byte(value), response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// createIndex assigns the owner the next index of the shared counter. Per
+// BIP-32 an index whose child key is invalid is skipped for the next one,
+// which is drawn from the counter too so no other owner is handed it.
+func (m *HDKeyManager) createIndex(ctx context.Context, owner common.Address) (*hdIndex, error) {
+	var index uint32
+	var derived *hdwallet.ExtendedKey
+	for attempt := 0; ; attempt++ {
+		next, err := m.storage.Increment(ctx, entity.KeyHDNextIndex())
+		if err != nil {
+			return nil, err
+		}
+
+		index = uint32(next - 1)
+		derived, err = m.derive(index)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, hdwallet.ErrInvalidKey) || attempt == _maxInvalidChildSkips {
+			return nil, fmt.Errorf("failed to derive account %d, %w", index, err)
+		}
+	}
+
+	record := &hdIndex{
+		Owner:   owner.Hex(),
+		Account: crypto.PubkeyToAddress(derived.ECDSA().PublicKey).Hex(),
+		Index:   index,
+	}
+
+	seralized, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := m.storage.WriteIfAbsent(ctx, entity.KeyHDIndex(owner), string(seralized), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		return m.getIndex(ctx, owner)
+	}
+
+	return record, nil
+}
+
+// LookupSigningAddress returns the owner's trading account without assigning
+// an index, entity.ErrNoAccountFound when there is none.
+func (m *HDKeyManager) LookupSigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	record, err := m.getIndex(ctx, owner)
+	switch {
+	case err == nil:
+		return common.HexToAddress(record.Account), nil
+	case errors.Is(err, entity.ErrEmpty):
+		return common.HexToAddress(""), entity.ErrNoAccountFound
+	default:
+		return common.HexToAddress(""), err
+	}
+}
+
+func (m *HDKeyManager) SigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	record, err := m.getIndex(ctx, owner)
+	switch {
+	case err == nil:
+		return common.HexToAddress(record.Account), nil
+	case !errors.Is(err, entity.ErrEmpty):
+		return common.HexToAddress(""), err
+	}
+
+	created, err := m.createIndex(ctx, owner)
+	if err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	return common.HexToAddress(created.Account), nil
+}
+
+func (m *HDKeyManager) SignTx(
+	ctx context.Context,
+	owner common.Address,
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	record, err := m.getIndex(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := m.derive(record.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.SignTx(transaction, types.LatestSignerForChainID(chainID), derived.ECDSA())
+}
@@ -8,4 +8,7 @@ import (
 type Storage interface {
 	Read(ctx context.Context, key string) (string, error)
 	Write(ctx context.Context, key string, data string, expiration time.Duration) error
+	WriteIfAbsent(ctx context.Context, key string, data string, expiration time.Duration) (bool, error)
+	Increment(ctx context.Context, key string) (int64, error)
+	Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error)
 }
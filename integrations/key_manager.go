@@ -5,6 +5,7 @@ import (
 	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,12 +13,15 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/envelope"
 )
 
 type key struct {
-	Account    string `json:"account"`
-	Owner      string `json:"owner"`
-	SigningKey string `json:"signingKey"`
+	Account    string           `json:"account"`
+	Owner      string           `json:"owner"`
+	SigningKey string           `json:"signingKey,omitempty"`
+	Sealed     *envelope.Sealed `json:"sealedKey,omitempty"`
+	KeyVersion int              `json:"keyVersion,omitempty"`
 }
 
 func (k *key) sign(transaction *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
@@ -26,17 +30,33 @@ func (k *key) sign(transaction *types.Transaction, chainID *big.Int) (*types.Tra
 		return nil, err
 	}
 
-	return types.SignTx(transaction, types.NewEIP155Signer(chainID), signingKey)
+	return types.SignTx(transaction, types.LatestSignerForChainID(chainID), signingKey)
 }
 
+const (
+	_rotationBatchSize = 100
+)
+
+type rotationResult int
+
+const (
+	_rotationFailed rotationResult = iota
+	_rotationSkipped
+	_rotationMigrated
+	_rotationRotated
+)
+
 type KeyManager struct {
-	storage Storage
+	storage  Storage
+	envelope *envelope.Envelope
 }
 
-func NewKeyManager(storage Storage) *KeyManager {
-	return &KeyManager{storage: storage}
+func NewKeyManager(storage Storage, envelope *envelope.Envelope) *KeyManager {
+	return &KeyManager{storage: storage, envelope: envelope}
 }
 
+// getAccount returns the account with its signing key decrypted in memory,
+// legacy plaintext records are sealed and written back on first read.
 func (m *KeyManager) getAccount(ctx context.Context, owner common.Address) (*key, error) {
 	value, err := m.storage.Read(ctx, entity.KeyAccount(owner))
 	if err != nil {
@@ -48,9 +68,61 @@ func (m *KeyManager) getAccount(ctx context.Context, owner common.Address) (*key
 		return nil, err
 	}
 
+	if response.Sealed == nil {
+		if response.SigningKey == "" {
+			return nil, entity.ErrNoAccountFound
+		}
+
+		sealed, err := m.sealAccount(response)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = m.writeAccount(ctx, sealed); err != nil {
+			return nil, fmt.Errorf("failed to migrate plaintext key, %w", err)
+		}
+
+		return response, nil
+	}
+
+	signingKey, err := m.envelope.Open(response.KeyVersion, response.Sealed, sealedOwner(owner))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key, %w", err)
+	}
+
+	response.SigningKey = string(signingKey)
 	return response, nil
 }
 
+func (m *KeyManager) sealAccount(account *key) (*key, error) {
+	sealed, err := m.envelope.Seal([]byte(account.SigningKey), sealedOwner(common.HexToAddress(account.Owner)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &key{
+		Account:    account.Account,
+		Owner:      account.Owner,
+		Sealed:     sealed,
+		KeyVersion: m.envelope.Version(),
+	}, nil
+}
+
+// sealedOwner is the additional data a signing key is sealed with, binding
+// it to the ACCOUNT record of its owner.
+func sealedOwner(owner common.Address) []byte {
+	return owner.Bytes()
+}
+
+func (m *KeyManager) writeAccount(ctx context.Context, record *key) error {
+	seralized, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return m.storage.Write(ctx, entity.KeyAccount(common.HexToAddress(record.Owner)), string(seralized), 0)
+}
+
 func (m *KeyManager) createNewAccount(ctx context.Context, owner common.Address) (*key, error) {
 	signingKey, err := crypto.GenerateKey()
 	if err != nil {
@@ -68,18 +140,50 @@ func (m *KeyManager) createNewAccount(ctx context.Context, owner common.Address)
 		SigningKey: hexutil.Encode(crypto.FromECDSA(signingKey))[2:],
 	}
 
-	seralized, err := json.Marshal(metadata)
+	record, err := m.sealAccount(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	seralized, err := json.Marshal(record)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = m.storage.Write(ctx, entity.KeyAccount(owner), string(seralized), 0); err != nil {
+	created, err := m.storage.WriteIfAbsent(ctx, entity.KeyAccount(owner), string(seralized), 0)
+	if err != nil {
 		return nil, err
 	}
 
+	// a concurrent request provisioned the owner first, its key is the one
+	// that must be used so funds are never sent to an orphaned account
+	if !created {
+		return m.getAccount(ctx, owner)
+	}
+
 	return metadata, nil
 }
 
+// LookupSigningAddress returns the owner's trading account without
+// provisioning one, entity.ErrNoAccountFound when there is none.
+func (m *KeyManager) LookupSigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	value, err := m.storage.Read(ctx, entity.KeyAccount(owner))
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return common.HexToAddress(""), entity.ErrNoAccountFound
+	default:
+		return common.HexToAddress(""), err
+	}
+
+	record := &key{}
+	if err = json.Unmarshal([]byte(value), record); err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	return common.HexToAddress(record.Account), nil
+}
+
 func (m *KeyManager) SigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
 	account, err := m.getAccount(ctx, owner)
 	switch {
@@ -91,7 +195,7 @@ func (m *KeyManager) SigningAddress(ctx context.Context, owner common.Address) (
 
 	created, err := m.createNewAccount(ctx, owner)
 	if err != nil {
-		return common.HexToAddress(""), nil
+		return common.HexToAddress(""), fmt.Errorf("failed to create trading account, %w", err)
 	}
 
 	return common.HexToAddress(created.Account), nil
@@ -110,3 +214,76 @@ func (m *KeyManager) SignTx(
 
 	return signingKey.sign(transaction, chainID)
 }
+
+// RotateMasterKey walks every stored account starting at cursor and re-wraps
+// its data key under the current master key. Records already at the current
+// version are skipped, so the rotation can be re-run or resumed from the
+// returned cursor after an interruption.
+func (m *KeyManager) RotateMasterKey(ctx context.Context, cursor uint64) (*entity.RotationReport, error) {
+	report := &entity.RotationReport{Cursor: cursor}
+	for {
+		keys, next, err := m.storage.Scan(ctx, report.Cursor, entity.KeyAccountPattern(), _rotationBatchSize)
+		if err != nil {
+			return report, err
+		}
+
+		for _, storageKey := range keys {
+			report.Scanned++
+			rotated, err := m.rotateAccount(ctx, storageKey)
+			switch {
+			case err != nil:
+				report.Failed++
+			case rotated == _rotationSkipped:
+				report.Skipped++
+			case rotated == _rotationMigrated:
+				report.Migrated++
+			default:
+				report.Rotated++
+			}
+		}
+
+		report.Cursor = next
+		if next == 0 {
+			return report, nil
+		}
+
+		if err = ctx.Err(); err != nil {
+			return report, err
+		}
+	}
+}
+
+func (m *KeyManager) rotateAccount(ctx context.Context, storageKey string) (rotationResult, error) {
+	value, err := m.storage.Read(ctx, storageKey)
+	if err != nil {
+		return _rotationFailed, err
+	}
+
+	record := &key{}
+	if err = json.Unmarshal([]byte(value), record); err != nil {
+		return _rotationFailed, err
+	}
+
+	switch {
+	case record.Sealed == nil && record.SigningKey != "":
+		sealed, err := m.sealAccount(record)
+		if err != nil {
+			return _rotationFailed, err
+		}
+
+		return _rotationMigrated, m.writeAccount(ctx, sealed)
+	case record.Sealed == nil:
+		return _rotationFailed, entity.ErrNoAccountFound
+	case record.KeyVersion == m.envelope.Version():
+		return _rotationSkipped, nil
+	}
+
+	sealed, err := m.envelope.Rewrap(record.KeyVersion, record.Sealed)
+	if err != nil {
+		return _rotationFailed, err
+	}
+
+	record.Sealed = sealed
+	record.KeyVersion = m.envelope.Version()
+	return _rotationRotated, m.writeAccount(ctx, record)
+}
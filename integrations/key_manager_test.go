@@ -0,0 +1,318 @@
+package integrations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/envelope"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+)
+
+func newTestStorage(t *testing.T) (*miniredis.Miniredis, *redis.Redis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: server.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	return server, storage
+}
+
+func newMasterKey(t *testing.T) []byte {
+	t.Helper()
+
+	masterKey := make([]byte, 32)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	return masterKey
+}
+
+func newTestEnvelope(t *testing.T, version int, masterKey []byte) *envelope.Envelope {
+	t.Helper()
+
+	keyEnvelope, err := envelope.New(version, masterKey)
+	if err != nil {
+		t.Fatalf("failed to create envelope: %v", err)
+	}
+
+	return keyEnvelope
+}
+
+// assertNoPlaintextKey fails when the raw Redis value of owner's account
+// contains signingKey in any of its usual encodings.
+func assertNoPlaintextKey(t *testing.T, server *miniredis.Miniredis, owner common.Address, signingKey string) {
+	t.Helper()
+
+	value, err := server.Get(entity.KeyAccount(owner))
+	if err != nil {
+		t.Fatalf("failed to read account record: %v", err)
+	}
+
+	stored := strings.ToLower(value)
+	for _, encoded := range []string{signingKey, "0x" + signingKey} {
+		if strings.Contains(stored, strings.ToLower(encoded)) {
+			t.Fatalf("account record contains the plaintext signing key: %s", value)
+		}
+	}
+
+	record := &key{}
+	if err = json.Unmarshal([]byte(value), record); err != nil {
+		t.Fatalf("failed to decode account record: %v", err)
+	}
+
+	if record.SigningKey != "" {
+		t.Fatalf("account record has a signingKey field: %s", value)
+	}
+
+	if record.Sealed == nil {
+		t.Fatalf("account record has no sealed key: %s", value)
+	}
+}
+
+func TestCreatedAccountStoresNoPlaintextKey(t *testing.T) {
+	ctx := context.Background()
+	server, storage := newTestStorage(t)
+	manager := NewKeyManager(storage, newTestEnvelope(t, envelope.InitialVersion, newMasterKey(t)))
+
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	address, err := manager.SigningAddress(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	account, err := manager.getAccount(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to read account: %v", err)
+	}
+
+	signingKey, err := crypto.HexToECDSA(account.SigningKey)
+	if err != nil {
+		t.Fatalf("decrypted signing key is invalid: %v", err)
+	}
+
+	if crypto.PubkeyToAddress(signingKey.PublicKey) != address {
+		t.Fatalf("decrypted signing key does not control %s", address.Hex())
+	}
+
+	assertNoPlaintextKey(t, server, owner, account.SigningKey)
+}
+
+func TestLegacyPlaintextAccountIsSealedOnFirstRead(t *testing.T) {
+	ctx := context.Background()
+	server, storage := newTestStorage(t)
+	manager := NewKeyManager(storage, newTestEnvelope(t, envelope.InitialVersion, newMasterKey(t)))
+
+	signingKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000a2")
+	account := crypto.PubkeyToAddress(signingKey.PublicKey)
+	plaintext := hexutil.Encode(crypto.FromECDSA(signingKey))[2:]
+	legacy, _ := json.Marshal(&key{Account: account.Hex(), Owner: owner.Hex(), SigningKey: plaintext})
+	if err = server.Set(entity.KeyAccount(owner), string(legacy)); err != nil {
+		t.Fatalf("failed to write legacy record: %v", err)
+	}
+
+	address, err := manager.SigningAddress(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to read legacy account: %v", err)
+	}
+
+	if address != account {
+		t.Fatalf("expected account %s, got %s", account.Hex(), address.Hex())
+	}
+
+	assertNoPlaintextKey(t, server, owner, plaintext)
+
+	migrated, err := manager.getAccount(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to read migrated account: %v", err)
+	}
+
+	if migrated.SigningKey != plaintext {
+		t.Fatal("migrated account does not decrypt to the legacy key")
+	}
+}
+
+func TestRotatedAccountStoresNoPlaintextKey(t *testing.T) {
+	ctx := context.Background()
+	server, storage := newTestStorage(t)
+	previousKey := newMasterKey(t)
+	previous := NewKeyManager(storage, newTestEnvelope(t, envelope.InitialVersion, previousKey))
+
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000a3")
+	if _, err := previous.SigningAddress(ctx, owner); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	account, err := previous.getAccount(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to read account: %v", err)
+	}
+
+	current := newTestEnvelope(t, envelope.InitialVersion+1, newMasterKey(t))
+	if err = current.WithPreviousKey(envelope.InitialVersion, previousKey); err != nil {
+		t.Fatalf("failed to register previous master key: %v", err)
+	}
+
+	rotating := NewKeyManager(storage, current)
+	report, err := rotating.RotateMasterKey(ctx, 0)
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	if report.Rotated != 1 || report.Failed != 0 {
+		t.Fatalf("unexpected rotation report %+v", report)
+	}
+
+	assertNoPlaintextKey(t, server, owner, account.SigningKey)
+
+	rotated, err := rotating.getAccount(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to read rotated account: %v", err)
+	}
+
+	if rotated.SigningKey != account.SigningKey {
+		t.Fatal("rotated account does not decrypt to the original key")
+	}
+}
+
+func TestSealedKeyIsBoundToItsOwner(t *testing.T) {
+	ctx := context.Background()
+	server, storage := newTestStorage(t)
+	manager := NewKeyManager(storage, newTestEnvelope(t, envelope.InitialVersion, newMasterKey(t)))
+
+	victim := common.HexToAddress("0x00000000000000000000000000000000000000a4")
+	attacker := common.HexToAddress("0x00000000000000000000000000000000000000a5")
+	if _, err := manager.SigningAddress(ctx, victim); err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	value, err := server.Get(entity.KeyAccount(victim))
+	if err != nil {
+		t.Fatalf("failed to read account record: %v", err)
+	}
+
+	record := &key{}
+	_ = json.Unmarshal([]byte(value), record)
+	record.Owner = attacker.Hex()
+	copied, _ := json.Marshal(record)
+	if err = server.Set(entity.KeyAccount(attacker), string(copied)); err != nil {
+		t.Fatalf("failed to copy account record: %v", err)
+	}
+
+	if _, err = manager.getAccount(ctx, attacker); err == nil {
+		t.Fatal("a sealed key copied to another owner's record was decrypted")
+	}
+}
+
+type signingAddresser interface {
+	SigningAddress(ctx context.Context, owner common.Address) (common.Address, error)
+}
+
+func TestConcurrentSigningAddressCreatesOneAccount(t *testing.T) {
+	const workers = 32
+
+	managers := map[string]func(t *testing.T, storage Storage) signingAddresser{
+		"sealed": func(t *testing.T, storage Storage) signingAddresser {
+			return NewKeyManager(storage, newTestEnvelope(t, envelope.InitialVersion, newMasterKey(t)))
+		},
+		"hd": func(t *testing.T, storage Storage) signingAddresser {
+			manager, err := NewHDKeyManager(storage, newMasterKey(t))
+			if err != nil {
+				t.Fatalf("failed to create hd key manager: %v", err)
+			}
+
+			return manager
+		},
+	}
+
+	for name, build := range managers {
+		t.Run(name, func(t *testing.T) {
+			_, storage := newTestStorage(t)
+			manager := build(t, storage)
+			owner := common.HexToAddress("0x00000000000000000000000000000000000000a6")
+
+			addresses := make([]common.Address, workers)
+			errs := make([]error, workers)
+			start := make(chan struct{})
+
+			var wg sync.WaitGroup
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					<-start
+					addresses[i], errs[i] = manager.SigningAddress(context.Background(), owner)
+				}(i)
+			}
+
+			close(start)
+			wg.Wait()
+
+			for i := range addresses {
+				if errs[i] != nil {
+					t.Fatalf("worker %d failed: %v", i, errs[i])
+				}
+
+				if addresses[i] != addresses[0] {
+					t.Fatalf("worker %d got %s, worker 0 got %s", i, addresses[i].Hex(), addresses[0].Hex())
+				}
+			}
+
+			stored, err := manager.SigningAddress(context.Background(), owner)
+			if err != nil {
+				t.Fatalf("failed to read account: %v", err)
+			}
+
+			if stored != addresses[0] {
+				t.Fatalf("stored account %s differs from handed out %s", stored.Hex(), addresses[0].Hex())
+			}
+		})
+	}
+}
+
+func TestLookupSigningAddressDoesNotProvision(t *testing.T) {
+	ctx := context.Background()
+	server, storage := newTestStorage(t)
+	manager := NewKeyManager(storage, newTestEnvelope(t, envelope.InitialVersion, newMasterKey(t)))
+
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000a7")
+	if _, err := manager.LookupSigningAddress(ctx, owner); !errors.Is(err, entity.ErrNoAccountFound) {
+		t.Fatalf("expected no account, got %v", err)
+	}
+
+	if server.Exists(entity.KeyAccount(owner)) {
+		t.Fatal("lookup provisioned an account")
+	}
+
+	created, err := manager.SigningAddress(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	found, err := manager.LookupSigningAddress(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to look up account: %v", err)
+	}
+
+	if found != created {
+		t.Fatalf("expected %s, got %s", created.Hex(), found.Hex())
+	}
+}
@@ -0,0 +1,85 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_keystoreBackend = "keystore"
+)
+
+// KeystoreKeyManager keeps trading keys as passphrase encrypted geth keyfiles
+// in a keystore directory.
+type KeystoreKeyManager struct {
+	directory  *accountDirectory
+	keystore   *keystore.KeyStore
+	passphrase string
+}
+
+func NewKeystoreKeyManager(storage Storage, dir string, passphrase string) (*KeystoreKeyManager, error) {
+	if passphrase == "" {
+		return nil, errors.New("keystore passphrase not configured")
+	}
+
+	return &KeystoreKeyManager{
+		directory:  &accountDirectory{storage: storage, backend: _keystoreBackend},
+		keystore:   keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP),
+		passphrase: passphrase,
+	}, nil
+}
+
+func (m *KeystoreKeyManager) LookupSigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	return m.directory.lookup(ctx, owner)
+}
+
+func (m *KeystoreKeyManager) SigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	account, err := m.directory.get(ctx, owner)
+	switch {
+	case err == nil:
+		return account, nil
+	case !errors.Is(err, entity.ErrEmpty):
+		return common.HexToAddress(""), err
+	}
+
+	created, err := m.keystore.NewAccount(m.passphrase)
+	if err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	return m.directory.putIfAbsent(ctx, owner, created.Address)
+}
+
+func (m *KeystoreKeyManager) SignTx(
+	ctx context.Context,
+	owner common.Address,
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	account, err := m.directory.get(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := accounts.Account{Address: account}
+	signed, err := m.keystore.SignTx(signer, transaction, chainID)
+	if !errors.Is(err, keystore.ErrLocked) {
+		return signed, err
+	}
+
+	// decrypting a keyfile runs scrypt, so each account is unlocked once and
+	// its key kept in memory for the following signatures.
+	if err = m.keystore.Unlock(signer, m.passphrase); err != nil {
+		return nil, fmt.Errorf("failed to unlock keystore account, %w", err)
+	}
+
+	return m.keystore.SignTx(signer, transaction, chainID)
+}
@@ -0,0 +1,66 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	_gasPriceOracleABI = `[{"inputs":[{"internalType":"bytes","name":"_data","type":"bytes"}],"name":"getL1Fee","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+)
+
+// L1FeeOracle estimates the L1 data fee an OP stack chain charges on top of
+// L2 execution for posting a transaction, through the GasPriceOracle
+// predeploy at 0x420000000000000000000000000000000000000F.
+type L1FeeOracle struct {
+	backend *ethclient.Client
+	address common.Address
+	abi     abi.ABI
+}
+
+func NewL1FeeOracle(backend *ethclient.Client, address common.Address) (*L1FeeOracle, error) {
+	oracleABI, err := abi.JSON(strings.NewReader(_gasPriceOracleABI))
+	if err != nil {
+		return nil, err
+	}
+
+	return &L1FeeOracle{backend: backend, address: address, abi: oracleABI}, nil
+}
+
+// L1Fee returns the data fee for transaction, chains without the oracle
+// predeploy report zero.
+func (o *L1FeeOracle) L1Fee(ctx context.Context, transaction *types.Transaction) (*big.Int, error) {
+	raw, err := transaction.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := o.abi.Pack("getL1Fee", raw)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := o.backend.CallContract(ctx, ethereum.CallMsg{To: &o.address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call gas price oracle, %w", err)
+	}
+
+	if len(out) == 0 {
+		return new(big.Int), nil
+	}
+
+	values, err := o.abi.Unpack("getL1Fee", out)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*big.Int), nil
+}
@@ -0,0 +1,155 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_remoteBackend = "remote"
+
+	_methodNewAccount      = "account_new"
+	_methodSignTransaction = "eth_signTransaction"
+)
+
+type signTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+type signTxResponse struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// RemoteSigner delegates key custody to a Clef or Web3Signer style JSON-RPC
+// signer, accounts are provisioned with account_new and transactions signed
+// with eth_signTransaction.
+type RemoteSigner struct {
+	directory *accountDirectory
+	client    *rpc.Client
+}
+
+func NewRemoteSigner(storage Storage, url string) (*RemoteSigner, error) {
+	client, err := rpc.DialHTTP(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer, %w", err)
+	}
+
+	return &RemoteSigner{
+		directory: &accountDirectory{storage: storage, backend: _remoteBackend},
+		client:    client,
+	}, nil
+}
+
+func (s *RemoteSigner) LookupSigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	return s.directory.lookup(ctx, owner)
+}
+
+func (s *RemoteSigner) SigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	account, err := s.directory.get(ctx, owner)
+	switch {
+	case err == nil:
+		return account, nil
+	case !errors.Is(err, entity.ErrEmpty):
+		return common.HexToAddress(""), err
+	}
+
+	var created common.Address
+	if err = s.client.CallContext(ctx, &created, _methodNewAccount); err != nil {
+		return common.HexToAddress(""), fmt.Errorf("failed to create remote account, %w", err)
+	}
+
+	return s.directory.putIfAbsent(ctx, owner, created)
+}
+
+func (s *RemoteSigner) SignTx(
+	ctx context.Context,
+	owner common.Address,
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	account, err := s.directory.get(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &signTxArgs{
+		From:    account,
+		To:      transaction.To(),
+		Gas:     hexutil.Uint64(transaction.Gas()),
+		Value:   (*hexutil.Big)(transaction.Value()),
+		Nonce:   hexutil.Uint64(transaction.Nonce()),
+		Data:    transaction.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+
+	if transaction.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(transaction.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(transaction.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(transaction.GasPrice())
+	}
+
+	var result json.RawMessage
+	if err = s.client.CallContext(ctx, &result, _methodSignTransaction, args); err != nil {
+		return nil, fmt.Errorf("failed to sign with remote signer, %w", err)
+	}
+
+	raw, err := decodeSignTxResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := new(types.Transaction)
+	if err = signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction, %w", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return nil, err
+	}
+
+	if sender != account {
+		return nil, fmt.Errorf("remote signer signed as %s, expected %s", sender.Hex(), account.Hex())
+	}
+
+	return signed, nil
+}
+
+// decodeSignTxResult accepts both the Clef object response and the bare raw
+// transaction hex returned by Web3Signer.
+func decodeSignTxResult(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+
+	response := &signTxResponse{}
+	if err := json.Unmarshal(result, response); err != nil {
+		return nil, fmt.Errorf("failed to parse signer response, %w", err)
+	}
+
+	if len(response.Raw) == 0 {
+		return nil, errors.New("signer returned an empty transaction")
+	}
+
+	return response.Raw, nil
+}
@@ -0,0 +1,194 @@
+package integrations
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// stubSigner stands in for Clef, serving account_new and eth_signTransaction
+// from a single in-memory key.
+type stubSigner struct {
+	key      *ecdsa.PrivateKey
+	created  atomic.Int32
+	wrapped  bool
+	signWith *ecdsa.PrivateKey
+}
+
+type stubAccountAPI struct {
+	signer *stubSigner
+}
+
+func (a *stubAccountAPI) New() common.Address {
+	a.signer.created.Add(1)
+	return crypto.PubkeyToAddress(a.signer.key.PublicKey)
+}
+
+type stubEthAPI struct {
+	signer *stubSigner
+}
+
+func (a *stubEthAPI) SignTransaction(args signTxArgs) (interface{}, error) {
+	transaction := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     uint64(args.Nonce),
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     args.Value.ToInt(),
+		Data:      args.Data,
+	})
+
+	key := a.signer.key
+	if a.signer.signWith != nil {
+		key = a.signer.signWith
+	}
+
+	signed, err := types.SignTx(transaction, types.LatestSignerForChainID(args.ChainID.ToInt()), key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	if a.signer.wrapped {
+		return &signTxResponse{Raw: raw}, nil
+	}
+
+	return hexutil.Bytes(raw), nil
+}
+
+func newStubSigner(t *testing.T) *stubSigner {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return &stubSigner{key: key}
+}
+
+func newTestRemoteSigner(t *testing.T, stub *stubSigner) *RemoteSigner {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &stubAccountAPI{signer: stub}); err != nil {
+		t.Fatalf("failed to register account api: %v", err)
+	}
+
+	if err := server.RegisterName("eth", &stubEthAPI{signer: stub}); err != nil {
+		t.Fatalf("failed to register eth api: %v", err)
+	}
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+
+	_, storage := newTestStorage(t)
+	signer, err := NewRemoteSigner(storage, httpServer.URL)
+	if err != nil {
+		t.Fatalf("failed to create remote signer: %v", err)
+	}
+
+	return signer
+}
+
+func newTestTransaction() *types.Transaction {
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(8453),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1_000_000),
+		GasFeeCap: big.NewInt(2_000_000_000),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+}
+
+func TestRemoteSignerProvisionsOneAccountPerOwner(t *testing.T) {
+	stub := newStubSigner(t)
+	signer := newTestRemoteSigner(t, stub)
+	owner := common.HexToAddress("0x1000000000000000000000000000000000000001")
+
+	first, err := signer.SigningAddress(context.Background(), owner)
+	if err != nil {
+		t.Fatalf("failed to provision account: %v", err)
+	}
+
+	second, err := signer.SigningAddress(context.Background(), owner)
+	if err != nil {
+		t.Fatalf("failed to read account: %v", err)
+	}
+
+	if expected := crypto.PubkeyToAddress(stub.key.PublicKey); first != expected || second != expected {
+		t.Fatalf("expected %s, got %s and %s", expected.Hex(), first.Hex(), second.Hex())
+	}
+
+	if created := stub.created.Load(); created != 1 {
+		t.Fatalf("expected a single account_new call, got %d", created)
+	}
+}
+
+func TestRemoteSignerSignsTransactions(t *testing.T) {
+	for _, wrapped := range []bool{false, true} {
+		stub := newStubSigner(t)
+		stub.wrapped = wrapped
+		signer := newTestRemoteSigner(t, stub)
+		owner := common.HexToAddress("0x1000000000000000000000000000000000000001")
+
+		account, err := signer.SigningAddress(context.Background(), owner)
+		if err != nil {
+			t.Fatalf("failed to provision account: %v", err)
+		}
+
+		transaction := newTestTransaction()
+		signed, err := signer.SignTx(context.Background(), owner, transaction, transaction.ChainId())
+		if err != nil {
+			t.Fatalf("wrapped=%t: failed to sign: %v", wrapped, err)
+		}
+
+		sender, err := types.Sender(types.LatestSignerForChainID(transaction.ChainId()), signed)
+		if err != nil {
+			t.Fatalf("wrapped=%t: failed to recover sender: %v", wrapped, err)
+		}
+
+		if sender != account {
+			t.Fatalf("wrapped=%t: expected sender %s, got %s", wrapped, account.Hex(), sender.Hex())
+		}
+
+		if signed.Nonce() != transaction.Nonce() || *signed.To() != *transaction.To() {
+			t.Fatalf("wrapped=%t: signer altered the transaction", wrapped)
+		}
+	}
+}
+
+func TestRemoteSignerRejectsForeignSignature(t *testing.T) {
+	stub := newStubSigner(t)
+	signer := newTestRemoteSigner(t, stub)
+	owner := common.HexToAddress("0x1000000000000000000000000000000000000001")
+
+	if _, err := signer.SigningAddress(context.Background(), owner); err != nil {
+		t.Fatalf("failed to provision account: %v", err)
+	}
+
+	stub.signWith = newStubSigner(t).key
+	transaction := newTestTransaction()
+	if _, err := signer.SignTx(context.Background(), owner, transaction, transaction.ChainId()); err == nil {
+		t.Fatal("expected a transaction signed by another key to be rejected")
+	}
+}
@@ -0,0 +1,225 @@
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	_keySize = 32
+
+	InitialVersion = 1
+)
+
+var (
+	ErrInvalidMasterKey = errors.New("master key must be 32 bytes hex encoded")
+	ErrNoMasterKey      = errors.New("master key not configured")
+	ErrMalformed        = errors.New("malformed sealed value")
+	ErrUnknownVersion   = errors.New("unknown master key version")
+)
+
+// Sealed is a value encrypted under a random data key, the data key itself
+// being encrypted under the master key.
+type Sealed struct {
+	Ciphertext string `json:"ciphertext"`
+	DataKey    string `json:"dataKey"`
+}
+
+// Envelope seals values under the current master key version and can open
+// values sealed under any registered version, allowing a rollover window.
+type Envelope struct {
+	version int
+	masters map[int]cipher.AEAD
+}
+
+func New(version int, masterKey []byte) (*Envelope, error) {
+	if version < InitialVersion {
+		return nil, ErrUnknownVersion
+	}
+
+	master, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{version: version, masters: map[int]cipher.AEAD{version: master}}, nil
+}
+
+// WithPreviousKey registers a retired master key that can still open values.
+func (e *Envelope) WithPreviousKey(version int, masterKey []byte) error {
+	if version == e.version {
+		return fmt.Errorf("version %d is the current master key", version)
+	}
+
+	master, err := newAEAD(masterKey)
+	if err != nil {
+		return err
+	}
+
+	e.masters[version] = master
+	return nil
+}
+
+func (e *Envelope) Version() int {
+	return e.version
+}
+
+// LoadMasterKey reads a hex encoded master key, preferring the inline value
+// over the key file.
+func LoadMasterKey(hexKey string, path string) ([]byte, error) {
+	if hexKey == "" && path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read master key file, %w", err)
+		}
+
+		hexKey = string(raw)
+	}
+
+	hexKey = strings.TrimPrefix(strings.TrimSpace(hexKey), "0x")
+	if hexKey == "" {
+		return nil, ErrNoMasterKey
+	}
+
+	key, err := hex.DecodeString(hexKey)
+	if err != nil || len(key) != _keySize {
+		return nil, ErrInvalidMasterKey
+	}
+
+	return key, nil
+}
+
+// Seal encrypts plaintext bound to additionalData, the value only opens again
+// with the same additionalData so it can not be moved to another record.
+func (e *Envelope) Seal(plaintext []byte, additionalData []byte) (*Sealed, error) {
+	dataKey := make([]byte, _keySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := seal(data, plaintext, additionalData)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := seal(e.masters[e.version], dataKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sealed{
+		Ciphertext: hex.EncodeToString(ciphertext),
+		DataKey:    hex.EncodeToString(wrapped),
+	}, nil
+}
+
+// Open decrypts a value sealed under the given master key version with the
+// additionalData it was sealed with, a zero version refers to records written
+// before versioning was introduced.
+func (e *Envelope) Open(version int, sealed *Sealed, additionalData []byte) ([]byte, error) {
+	dataKey, err := e.unwrap(version, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := hex.DecodeString(sealed.Ciphertext)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	return open(data, ciphertext, additionalData)
+}
+
+// Rewrap re-encrypts the data key of a sealed value under the current master
+// key, the ciphertext itself is left untouched.
+func (e *Envelope) Rewrap(version int, sealed *Sealed) (*Sealed, error) {
+	dataKey, err := e.unwrap(version, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := seal(e.masters[e.version], dataKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sealed{
+		Ciphertext: sealed.Ciphertext,
+		DataKey:    hex.EncodeToString(wrapped),
+	}, nil
+}
+
+func (e *Envelope) unwrap(version int, sealed *Sealed) ([]byte, error) {
+	if sealed == nil {
+		return nil, ErrMalformed
+	}
+
+	if version == 0 {
+		version = InitialVersion
+	}
+
+	master, ok := e.masters[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	wrapped, err := hex.DecodeString(sealed.DataKey)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	dataKey, err := open(master, wrapped, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key, %w", err)
+	}
+
+	return dataKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != _keySize {
+		return nil, ErrInvalidMasterKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	nonce, body := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, body, additionalData)
+}
@@ -0,0 +1,80 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func newTestEnvelope(t *testing.T, version int) (*Envelope, []byte) {
+	t.Helper()
+
+	masterKey := make([]byte, _keySize)
+	if _, err := rand.Read(masterKey); err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	envelope, err := New(version, masterKey)
+	if err != nil {
+		t.Fatalf("failed to create envelope: %v", err)
+	}
+
+	return envelope, masterKey
+}
+
+func TestSealOpensWithSameAdditionalData(t *testing.T) {
+	envelope, _ := newTestEnvelope(t, InitialVersion)
+	plaintext := []byte("signing key")
+	owner := []byte("owner-a")
+
+	sealed, err := envelope.Seal(plaintext, owner)
+	if err != nil {
+		t.Fatalf("failed to seal: %v", err)
+	}
+
+	opened, err := envelope.Open(InitialVersion, sealed, owner)
+	if err != nil {
+		t.Fatalf("failed to open: %v", err)
+	}
+
+	if !bytes.Equal(opened, plaintext) {
+		t.Fatalf("expected %q, got %q", plaintext, opened)
+	}
+
+	if _, err = envelope.Open(InitialVersion, sealed, []byte("owner-b")); err == nil {
+		t.Fatal("sealed value opened with different additional data")
+	}
+}
+
+func TestRewrapKeepsValueOpenable(t *testing.T) {
+	previous, previousKey := newTestEnvelope(t, InitialVersion)
+	owner := []byte("owner")
+
+	sealed, err := previous.Seal([]byte("signing key"), owner)
+	if err != nil {
+		t.Fatalf("failed to seal: %v", err)
+	}
+
+	current, _ := newTestEnvelope(t, InitialVersion+1)
+	if err = current.WithPreviousKey(InitialVersion, previousKey); err != nil {
+		t.Fatalf("failed to register previous key: %v", err)
+	}
+
+	rewrapped, err := current.Rewrap(InitialVersion, sealed)
+	if err != nil {
+		t.Fatalf("failed to rewrap: %v", err)
+	}
+
+	opened, err := current.Open(current.Version(), rewrapped, owner)
+	if err != nil {
+		t.Fatalf("failed to open rewrapped value: %v", err)
+	}
+
+	if string(opened) != "signing key" {
+		t.Fatalf("unexpected plaintext %q", opened)
+	}
+
+	if _, err = previous.Open(InitialVersion, rewrapped, owner); err == nil {
+		t.Fatal("rewrapped value opened under the retired master key")
+	}
+}
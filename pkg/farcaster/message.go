@@ -0,0 +1,242 @@
+package farcaster
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeebo/blake3"
+)
+
+const (
+	MessageTypeFrameAction = 13
+
+	_hashSchemeBlake3       = 1
+	_signatureSchemeEd25519 = 1
+	_hashSize               = 20
+
+	_wireVarint = 0
+	_wireBytes  = 2
+	_wire32     = 5
+	_wire64     = 1
+)
+
+var (
+	// Epoch is the start of Farcaster time, message timestamps are seconds
+	// since this instant.
+	Epoch = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	ErrMalformedMessage = errors.New("malformed farcaster message")
+	ErrInvalidHash      = errors.New("farcaster message hash mismatch")
+	ErrInvalidSignature = errors.New("invalid farcaster message signature")
+)
+
+type CastID struct {
+	Fid  uint64
+	Hash []byte
+}
+
+type FrameActionBody struct {
+	URL           []byte
+	ButtonIndex   uint32
+	CastID        CastID
+	InputText     []byte
+	State         []byte
+	TransactionID []byte
+	Address       []byte
+}
+
+type MessageData struct {
+	Type        uint64
+	Fid         uint64
+	Timestamp   uint32
+	Network     uint64
+	FrameAction *FrameActionBody
+}
+
+// Message is a decoded protobuf Farcaster message, DataBytes holds the exact
+// serialized MessageData the hash was computed over.
+type Message struct {
+	Data            MessageData
+	DataBytes       []byte
+	Hash            []byte
+	HashScheme      uint64
+	Signature       []byte
+	SignatureScheme uint64
+	Signer          []byte
+}
+
+func (d MessageData) Time() time.Time {
+	return Epoch.Add(time.Duration(d.Timestamp) * time.Second)
+}
+
+func DecodeMessage(raw []byte) (*Message, error) {
+	message := &Message{}
+	var dataField []byte
+	err := walk(raw, func(field uint64, wire uint64, varint uint64, value []byte) error {
+		switch {
+		case field == 1 && wire == _wireBytes:
+			dataField = value
+		case field == 2 && wire == _wireBytes:
+			message.Hash = value
+		case field == 3 && wire == _wireVarint:
+			message.HashScheme = varint
+		case field == 4 && wire == _wireBytes:
+			message.Signature = value
+		case field == 5 && wire == _wireVarint:
+			message.SignatureScheme = varint
+		case field == 6 && wire == _wireBytes:
+			message.Signer = value
+		case field == 7 && wire == _wireBytes:
+			message.DataBytes = value
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if message.DataBytes == nil {
+		message.DataBytes = dataField
+	}
+
+	if message.DataBytes == nil {
+		return nil, fmt.Errorf("%w: missing data", ErrMalformedMessage)
+	}
+
+	if err = decodeData(message.DataBytes, &message.Data); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Verify checks the blake3 hash of the message data and the ed25519
+// signature of the hash by the message signer.
+func (m *Message) Verify() error {
+	if m.HashScheme != _hashSchemeBlake3 || m.SignatureScheme != _signatureSchemeEd25519 {
+		return fmt.Errorf("%w: unsupported scheme", ErrMalformedMessage)
+	}
+
+	sum := blake3.Sum256(m.DataBytes)
+	if !bytes.Equal(sum[:_hashSize], m.Hash) {
+		return ErrInvalidHash
+	}
+
+	if len(m.Signer) != ed25519.PublicKeySize || !ed25519.Verify(m.Signer, m.Hash, m.Signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func decodeData(raw []byte, data *MessageData) error {
+	return walk(raw, func(field uint64, wire uint64, varint uint64, value []byte) error {
+		switch {
+		case field == 1 && wire == _wireVarint:
+			data.Type = varint
+		case field == 2 && wire == _wireVarint:
+			data.Fid = varint
+		case field == 3 && wire == _wireVarint:
+			data.Timestamp = uint32(varint)
+		case field == 4 && wire == _wireVarint:
+			data.Network = varint
+		case field == 16 && wire == _wireBytes:
+			data.FrameAction = &FrameActionBody{}
+			return decodeFrameAction(value, data.FrameAction)
+		}
+
+		return nil
+	})
+}
+
+func decodeFrameAction(raw []byte, body *FrameActionBody) error {
+	return walk(raw, func(field uint64, wire uint64, varint uint64, value []byte) error {
+		switch {
+		case field == 1 && wire == _wireBytes:
+			body.URL = value
+		case field == 2 && wire == _wireVarint:
+			body.ButtonIndex = uint32(varint)
+		case field == 3 && wire == _wireBytes:
+			return walk(value, func(field uint64, wire uint64, varint uint64, value []byte) error {
+				switch {
+				case field == 1 && wire == _wireVarint:
+					body.CastID.Fid = varint
+				case field == 2 && wire == _wireBytes:
+					body.CastID.Hash = value
+				}
+
+				return nil
+			})
+		case field == 4 && wire == _wireBytes:
+			body.InputText = value
+		case field == 5 && wire == _wireBytes:
+			body.State = value
+		case field == 6 && wire == _wireBytes:
+			body.TransactionID = value
+		case field == 7 && wire == _wireBytes:
+			body.Address = value
+		}
+
+		return nil
+	})
+}
+
+// walk iterates the top level fields of a protobuf encoded message.
+func walk(raw []byte, visit func(field uint64, wire uint64, varint uint64, value []byte) error) error {
+	for len(raw) > 0 {
+		tag, n := binary.Uvarint(raw)
+		if n <= 0 {
+			return ErrMalformedMessage
+		}
+
+		raw = raw[n:]
+		field, wire := tag>>3, tag&0x7
+
+		var (
+			varint uint64
+			value  []byte
+		)
+		switch wire {
+		case _wireVarint:
+			varint, n = binary.Uvarint(raw)
+			if n <= 0 {
+				return ErrMalformedMessage
+			}
+
+			raw = raw[n:]
+		case _wireBytes:
+			size, n := binary.Uvarint(raw)
+			if n <= 0 || uint64(len(raw)-n) < size {
+				return ErrMalformedMessage
+			}
+
+			value = raw[n : n+int(size)]
+			raw = raw[n+int(size):]
+		case _wire32:
+			if len(raw) < 4 {
+				return ErrMalformedMessage
+			}
+
+			raw = raw[4:]
+		case _wire64:
+			if len(raw) < 8 {
+				return ErrMalformedMessage
+			}
+
+			raw = raw[8:]
+		default:
+			return ErrMalformedMessage
+		}
+
+		if err := visit(field, wire, varint, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
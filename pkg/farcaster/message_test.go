@@ -0,0 +1,164 @@
+package farcaster
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeebo/blake3"
+)
+
+func appendVarintField(buf []byte, field uint64, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, field<<3|_wireVarint)
+	return binary.AppendUvarint(buf, value)
+}
+
+func appendBytesField(buf []byte, field uint64, value []byte) []byte {
+	buf = binary.AppendUvarint(buf, field<<3|_wireBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func encodeFrameAction(fid uint64, timestamp uint32, url string, state string) []byte {
+	var castID []byte
+	castID = appendVarintField(castID, 1, fid)
+	castID = appendBytesField(castID, 2, []byte{0xca, 0x57})
+
+	var body []byte
+	body = appendBytesField(body, 1, []byte(url))
+	body = appendVarintField(body, 2, 1)
+	body = appendBytesField(body, 3, castID)
+	body = appendBytesField(body, 5, []byte(state))
+
+	var data []byte
+	data = appendVarintField(data, 1, MessageTypeFrameAction)
+	data = appendVarintField(data, 2, fid)
+	data = appendVarintField(data, 3, uint64(timestamp))
+	data = appendVarintField(data, 4, 1)
+	return appendBytesField(data, 16, body)
+}
+
+func signMessage(key ed25519.PrivateKey, data []byte) []byte {
+	sum := blake3.Sum256(data)
+	hash := sum[:_hashSize]
+
+	var message []byte
+	message = appendBytesField(message, 1, data)
+	message = appendBytesField(message, 2, hash)
+	message = appendVarintField(message, 3, _hashSchemeBlake3)
+	message = appendBytesField(message, 4, ed25519.Sign(key, hash))
+	message = appendVarintField(message, 5, _signatureSchemeEd25519)
+	return appendBytesField(message, 6, key.Public().(ed25519.PublicKey))
+}
+
+func newTestKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key
+}
+
+func TestDecodeMessageVerifies(t *testing.T) {
+	key := newTestKey(t)
+	raw := signMessage(key, encodeFrameAction(42, 100, "https://frames.example.com/buy", "state"))
+
+	message, err := DecodeMessage(raw)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if err = message.Verify(); err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	body := message.Data.FrameAction
+	switch {
+	case message.Data.Type != MessageTypeFrameAction || message.Data.Fid != 42:
+		t.Fatalf("unexpected data %+v", message.Data)
+	case body == nil || string(body.URL) != "https://frames.example.com/buy" || string(body.State) != "state":
+		t.Fatalf("unexpected frame action %+v", body)
+	case body.ButtonIndex != 1 || body.CastID.Fid != 42:
+		t.Fatalf("unexpected frame action %+v", body)
+	case !message.Data.Time().Equal(Epoch.Add(100 * time.Second)):
+		t.Fatalf("unexpected timestamp %s", message.Data.Time())
+	}
+}
+
+func TestVerifyRejectsTamperedMessages(t *testing.T) {
+	key := newTestKey(t)
+	data := encodeFrameAction(42, 100, "https://frames.example.com/buy", "state")
+
+	tests := []struct {
+		name   string
+		modify func(message *Message)
+		want   error
+	}{
+		{
+			name: "tampered body",
+			modify: func(message *Message) {
+				message.DataBytes = encodeFrameAction(42, 100, "https://frames.example.com/buy", "other")
+			},
+			want: ErrInvalidHash,
+		},
+		{
+			name:   "tampered hash",
+			modify: func(message *Message) { message.Hash[0] ^= 0xff },
+			want:   ErrInvalidHash,
+		},
+		{
+			name:   "other signer",
+			modify: func(message *Message) { message.Signer = newTestKey(t).Public().(ed25519.PublicKey) },
+			want:   ErrInvalidSignature,
+		},
+		{
+			name:   "truncated signer",
+			modify: func(message *Message) { message.Signer = message.Signer[:8] },
+			want:   ErrInvalidSignature,
+		},
+		{
+			name:   "unsupported scheme",
+			modify: func(message *Message) { message.SignatureScheme = 2 },
+			want:   ErrMalformedMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := DecodeMessage(signMessage(key, data))
+			if err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+
+			tt.modify(message)
+			if err = message.Verify(); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDecodeMessageRejectsMalformedBytes(t *testing.T) {
+	raw := signMessage(newTestKey(t), encodeFrameAction(42, 100, "https://frames.example.com/buy", ""))
+
+	tests := map[string][]byte{
+		"truncated":    raw[:len(raw)-4],
+		"missing data": appendVarintField(nil, 3, _hashSchemeBlake3),
+		"bad wire":     {0x0f},
+		"bad varint":   {0x08, 0xff},
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeMessage(raw); !errors.Is(err, ErrMalformedMessage) {
+				t.Fatalf("expected a malformed message, got %v", err)
+			}
+		})
+	}
+}
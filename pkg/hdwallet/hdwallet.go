@@ -0,0 +1,123 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	_minSeedSize = 16
+	_maxSeedSize = 64
+
+	_hardenedKeyStart = 0x80000000
+)
+
+var (
+	ErrInvalidSeed = errors.New("seed must be 16 to 64 bytes hex encoded")
+	ErrInvalidKey  = errors.New("derived key is invalid")
+)
+
+// ExtendedKey is a BIP-32 extended private key.
+type ExtendedKey struct {
+	key       *big.Int
+	chainCode []byte
+}
+
+func LoadSeed(hexSeed string, path string) ([]byte, error) {
+	if hexSeed == "" && path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seed file, %w", err)
+		}
+
+		hexSeed = string(raw)
+	}
+
+	seed, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(hexSeed), "0x"))
+	if err != nil || len(seed) < _minSeedSize || len(seed) > _maxSeedSize {
+		return nil, ErrInvalidSeed
+	}
+
+	return seed, nil
+}
+
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < _minSeedSize || len(seed) > _maxSeedSize {
+		return nil, ErrInvalidSeed
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	return &ExtendedKey{key: key, chainCode: sum[32:]}, nil
+}
+
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	data := make([]byte, 0, 37)
+	if index >= _hardenedKeyStart {
+		data = append(data, 0x00)
+		data = append(data, k.privateBytes()...)
+	} else {
+		data = append(data, crypto.CompressPubkey(&k.ECDSA().PublicKey)...)
+	}
+
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	child := new(big.Int).Add(tweak, k.key)
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	return &ExtendedKey{key: child, chainCode: sum[32:]}, nil
+}
+
+func (k *ExtendedKey) Derive(path accounts.DerivationPath) (*ExtendedKey, error) {
+	current := k
+	for _, index := range path {
+		child, err := current.Child(index)
+		if err != nil {
+			return nil, err
+		}
+
+		current = child
+	}
+
+	return current, nil
+}
+
+func (k *ExtendedKey) ECDSA() *ecdsa.PrivateKey {
+	privateKey, _ := crypto.ToECDSA(k.privateBytes())
+	return privateKey
+}
+
+func (k *ExtendedKey) privateBytes() []byte {
+	return k.key.FillBytes(make([]byte, 32))
+}
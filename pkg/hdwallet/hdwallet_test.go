@@ -0,0 +1,113 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func mustDecode(t *testing.T, value string) []byte {
+	t.Helper()
+
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		t.Fatalf("failed to decode %q: %v", value, err)
+	}
+
+	return decoded
+}
+
+// TestBIP32Vector1 walks the chain of BIP-32 test vector 1.
+func TestBIP32Vector1(t *testing.T) {
+	master, err := NewMaster(mustDecode(t, "000102030405060708090a0b0c0d0e0f"))
+	if err != nil {
+		t.Fatalf("failed to create master key: %v", err)
+	}
+
+	steps := []struct {
+		name       string
+		index      uint32
+		chainCode  string
+		privateKey string
+	}{
+		{
+			name:       "m/0H",
+			index:      _hardenedKeyStart,
+			chainCode:  "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+			privateKey: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		},
+		{
+			name:       "m/0H/1",
+			index:      1,
+			chainCode:  "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+			privateKey: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		},
+		{
+			name:       "m/0H/1/2H",
+			index:      _hardenedKeyStart + 2,
+			chainCode:  "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f",
+			privateKey: "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		},
+		{
+			name:       "m/0H/1/2H/2",
+			index:      2,
+			chainCode:  "cfb71883f01676f587d023cc53a35bc7f88f724b1f8c2892ac1275ac822a3edd",
+			privateKey: "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4",
+		},
+		{
+			name:       "m/0H/1/2H/2/1000000000",
+			index:      1000000000,
+			chainCode:  "c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e",
+			privateKey: "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+		},
+	}
+
+	if got := hex.EncodeToString(master.chainCode); got != "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508" {
+		t.Fatalf("m: unexpected chain code %s", got)
+	}
+
+	if got := hex.EncodeToString(master.privateBytes()); got != "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35" {
+		t.Fatalf("m: unexpected private key %s", got)
+	}
+
+	current := master
+	for _, step := range steps {
+		current, err = current.Child(step.index)
+		if err != nil {
+			t.Fatalf("%s: failed to derive: %v", step.name, err)
+		}
+
+		if got := hex.EncodeToString(current.chainCode); got != step.chainCode {
+			t.Fatalf("%s: expected chain code %s, got %s", step.name, step.chainCode, got)
+		}
+
+		if got := hex.EncodeToString(current.privateBytes()); got != step.privateKey {
+			t.Fatalf("%s: expected private key %s, got %s", step.name, step.privateKey, got)
+		}
+	}
+}
+
+// TestBIP44EthereumVector derives m/44'/60'/0'/0/0 from the seed of the
+// "abandon ... about" mnemonic, the first account every BIP-44 wallet shows.
+func TestBIP44EthereumVector(t *testing.T) {
+	seed := mustDecode(t, "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc1"+
+		"9a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatalf("failed to create master key: %v", err)
+	}
+
+	derived, err := master.Derive(accounts.DefaultBaseDerivationPath)
+	if err != nil {
+		t.Fatalf("failed to derive: %v", err)
+	}
+
+	expected := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	if got := crypto.PubkeyToAddress(derived.ECDSA().PublicKey); got != expected {
+		t.Fatalf("expected %s, got %s", expected.Hex(), got.Hex())
+	}
+}
@@ -9,6 +9,13 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+var _deleteIfEqual = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
 type RedisConfig struct {
 	Addr     string
 	UserName string
@@ -42,6 +49,20 @@ func (r *Redis) Read(ctx context.Context, key string) (string, error) {
 	return value, nil
 }
 
+func (r *Redis) ReadAndDelete(ctx context.Context, key string) (string, error) {
+	value, err := r.client.GetDel(ctx, key).Result()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return "", entity.ErrEmpty
+	case err != nil:
+		return "", err
+	case value == "":
+		return "", entity.ErrEmpty
+	}
+
+	return value, nil
+}
+
 func (r *Redis) Write(ctx context.Context, key string, data string, expiration time.Duration) error {
 	if _, err := r.client.Set(ctx, key, data, expiration).Result(); err != nil {
 		return err
@@ -49,3 +70,42 @@ func (r *Redis) Write(ctx context.Context, key string, data string, expiration t
 
 	return nil
 }
+
+func (r *Redis) WriteIfAbsent(ctx context.Context, key string, data string, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, data, expiration).Result()
+}
+
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+// DeleteIfEqual deletes key only while it still holds value, returning
+// whether it was deleted.
+func (r *Redis) DeleteIfEqual(ctx context.Context, key string, value string) (bool, error) {
+	deleted, err := _deleteIfEqual.Run(ctx, r.client, []string{key}, value).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted == 1, nil
+}
+
+func (r *Redis) AddMember(ctx context.Context, key string, member string) error {
+	return r.client.SAdd(ctx, key, member).Err()
+}
+
+func (r *Redis) RemoveMember(ctx context.Context, key string, member string) error {
+	return r.client.SRem(ctx, key, member).Err()
+}
+
+func (r *Redis) Members(ctx context.Context, key string) ([]string, error) {
+	return r.client.SMembers(ctx, key).Result()
+}
+
+func (r *Redis) Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
+	return r.client.Scan(ctx, cursor, match, count).Result()
+}
+
+func (r *Redis) Increment(ctx context.Context, key string) (int64, error) {
+	return r.client.Incr(ctx, key).Result()
+}
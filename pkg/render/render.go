@@ -0,0 +1,145 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	_wideWidth   = 1146
+	_squareWidth = 600
+	_height      = 600
+	_padding     = 48
+	_logoSize    = 160
+)
+
+var (
+	_background = color.RGBA{R: 0x17, G: 0x10, B: 0x2b, A: 0xff}
+	_foreground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	_muted      = color.RGBA{R: 0xa8, G: 0x9f, B: 0xc4, A: 0xff}
+	_accent     = color.RGBA{R: 0x8a, G: 0x63, B: 0xd2, A: 0xff}
+
+	_regular = mustParse(goregular.TTF)
+	_bold    = mustParse(gobold.TTF)
+)
+
+// Line is a single row of text on a card.
+type Line struct {
+	Text  string
+	Size  float64
+	Bold  bool
+	Muted bool
+}
+
+// Card is a logo followed by rows of text, rendered to a 1.91:1 or 1:1 PNG.
+type Card struct {
+	Wide  bool
+	Logo  image.Image
+	Title string
+	Lines []Line
+}
+
+func (c *Card) PNG() ([]byte, error) {
+	width := _squareWidth
+	if c.Wide {
+		width = _wideWidth
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, _height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(_background), image.Point{}, draw.Src)
+
+	logoRect := image.Rect(_padding, _padding, _padding+_logoSize, _padding+_logoSize)
+	if c.Logo != nil {
+		draw.CatmullRom.Scale(canvas, logoRect, c.Logo, c.Logo.Bounds(), draw.Over, nil)
+	} else {
+		draw.Draw(canvas, logoRect, image.NewUniform(_accent), image.Point{}, draw.Src)
+	}
+
+	textX := _padding
+	y := _padding + _logoSize + _padding
+	if c.Wide {
+		textX = _padding*2 + _logoSize
+		y = _padding
+	}
+
+	lines := append([]Line{{Text: c.Title, Size: 56, Bold: true}}, c.Lines...)
+	for _, line := range lines {
+		face, err := newFace(line)
+		if err != nil {
+			return nil, err
+		}
+
+		y += face.Metrics().Ascent.Ceil()
+		ink := _foreground
+		if line.Muted {
+			ink = _muted
+		}
+
+		drawer := &font.Drawer{
+			Dst:  canvas,
+			Src:  image.NewUniform(ink),
+			Face: face,
+			Dot:  fixed.P(textX, y),
+		}
+		drawer.DrawString(truncate(drawer, line.Text, width-textX-_padding))
+		y += face.Metrics().Descent.Ceil() + 12
+		_ = face.Close()
+	}
+
+	encoded := &bytes.Buffer{}
+	if err := png.Encode(encoded, canvas); err != nil {
+		return nil, err
+	}
+
+	return encoded.Bytes(), nil
+}
+
+func newFace(line Line) (font.Face, error) {
+	typeface := _regular
+	if line.Bold {
+		typeface = _bold
+	}
+
+	size := line.Size
+	if size == 0 {
+		size = 32
+	}
+
+	return opentype.NewFace(typeface, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// truncate shortens text with an ellipsis until it fits within maxWidth.
+func truncate(drawer *font.Drawer, text string, maxWidth int) string {
+	if drawer.MeasureString(text).Ceil() <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := string(runes) + "…"
+		if drawer.MeasureString(candidate).Ceil() <= maxWidth {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+func mustParse(ttf []byte) *opentype.Font {
+	parsed, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
@@ -52,3 +52,11 @@ func (s *Server) Shutdown() error {
 func ResponseJSON(c echo.Context, status int, response interface{}) error {
 	return c.JSON(status, response)
 }
+
+func ResponseHTML(c echo.Context, status int, html string) error {
+	return c.HTML(status, html)
+}
+
+func ResponseBlob(c echo.Context, status int, contentType string, data []byte) error {
+	return c.Blob(status, contentType, data)
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid session token")
+	ErrExpiredToken = errors.New("session token expired")
+)
+
+type Claims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Issuer signs and verifies HMAC-SHA256 session tokens of the form
+// base64url(claims).base64url(mac).
+type Issuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewIssuer(secret string, ttl time.Duration) (*Issuer, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("session secret must be at least 32 characters")
+	}
+
+	return &Issuer{secret: []byte(secret), ttl: ttl}, nil
+}
+
+func (i *Issuer) Issue(subject string) (string, time.Time, error) {
+	expiresAt := time.Now().Add(i.ttl)
+	payload, err := json.Marshal(&Claims{Subject: subject, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(i.mac(encoded)), expiresAt, nil
+}
+
+func (i *Issuer) Verify(token string) (*Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, i.mac(encoded)) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{}
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+func (i *Issuer) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, i.secret)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
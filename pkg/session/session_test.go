@@ -0,0 +1,94 @@
+package session
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const _testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestIssuer(t *testing.T, secret string, ttl time.Duration) *Issuer {
+	t.Helper()
+
+	issuer, err := NewIssuer(secret, ttl)
+	if err != nil {
+		t.Fatalf("failed to create issuer: %v", err)
+	}
+
+	return issuer
+}
+
+func TestNewIssuerRejectsShortSecret(t *testing.T) {
+	if _, err := NewIssuer("short", time.Hour); err == nil {
+		t.Fatal("expected a short secret to be rejected")
+	}
+}
+
+func TestIssueVerifies(t *testing.T) {
+	issuer := newTestIssuer(t, _testSecret, time.Hour)
+
+	token, expiresAt, err := issuer.Issue("owner")
+	if err != nil {
+		t.Fatalf("failed to issue: %v", err)
+	}
+
+	claims, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	if claims.Subject != "owner" || claims.ExpiresAt != expiresAt.Unix() {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	issuer := newTestIssuer(t, _testSecret, time.Hour)
+
+	token, _, err := issuer.Issue("owner")
+	if err != nil {
+		t.Fatalf("failed to issue: %v", err)
+	}
+
+	forged, _, err := newTestIssuer(t, strings.Repeat("x", 32), time.Hour).Issue("owner")
+	if err != nil {
+		t.Fatalf("failed to issue: %v", err)
+	}
+
+	encoded, signature, _ := strings.Cut(token, ".")
+	tampered := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"attacker","exp":9999999999}`))
+
+	tests := map[string]string{
+		"forged secret":    forged,
+		"tampered claims":  tampered + "." + signature,
+		"tampered mac":     encoded + "." + base64.RawURLEncoding.EncodeToString([]byte("mac")),
+		"missing mac":      encoded,
+		"malformed mac":    encoded + ".!!",
+		"malformed claims": "!!." + signature,
+		"empty":            "",
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := issuer.Verify(token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("expected an invalid token, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsExpiredToken(t *testing.T) {
+	issuer := newTestIssuer(t, _testSecret, -time.Minute)
+
+	token, _, err := issuer.Issue("owner")
+	if err != nil {
+		t.Fatalf("failed to issue: %v", err)
+	}
+
+	if _, err = issuer.Verify(token); !errors.Is(err, ErrExpiredToken) {
+		t.Fatalf("expected an expired token, got %v", err)
+	}
+}
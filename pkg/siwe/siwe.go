@@ -0,0 +1,171 @@
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	_headerSuffix = " wants you to sign in with your Ethereum account:"
+
+	_fieldURI            = "URI"
+	_fieldVersion        = "Version"
+	_fieldChainID        = "Chain ID"
+	_fieldNonce          = "Nonce"
+	_fieldIssuedAt       = "Issued At"
+	_fieldExpirationTime = "Expiration Time"
+	_fieldNotBefore      = "Not Before"
+	_fieldRequestID      = "Request ID"
+	_fieldResources      = "Resources"
+)
+
+var (
+	ErrMalformedMessage = errors.New("malformed siwe message")
+	ErrInvalidSignature = errors.New("invalid siwe signature")
+)
+
+// Message is an EIP-4361 Sign-In With Ethereum message.
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+func Parse(raw string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], _headerSuffix) {
+		return nil, ErrMalformedMessage
+	}
+
+	if !common.IsHexAddress(lines[1]) {
+		return nil, fmt.Errorf("%w: invalid address", ErrMalformedMessage)
+	}
+
+	message := &Message{
+		Domain:  strings.TrimSuffix(lines[0], _headerSuffix),
+		Address: common.HexToAddress(lines[1]),
+	}
+
+	var (
+		statement   []string
+		inResources bool
+		err         error
+	)
+	for _, line := range lines[2:] {
+		if inResources && strings.HasPrefix(line, "- ") {
+			message.Resources = append(message.Resources, strings.TrimPrefix(line, "- "))
+			continue
+		}
+
+		field, value, ok := strings.Cut(line, ": ")
+		if line == _fieldResources+":" {
+			inResources = true
+			continue
+		}
+
+		if !ok {
+			if message.URI == "" && line != "" {
+				statement = append(statement, line)
+			}
+
+			continue
+		}
+
+		switch field {
+		case _fieldURI:
+			message.URI = value
+		case _fieldVersion:
+			message.Version = value
+		case _fieldChainID:
+			message.ChainID, err = strconv.ParseInt(value, 10, 64)
+		case _fieldNonce:
+			message.Nonce = value
+		case _fieldIssuedAt:
+			message.IssuedAt, err = time.Parse(time.RFC3339, value)
+		case _fieldExpirationTime:
+			message.ExpirationTime, err = parseOptionalTime(value)
+		case _fieldNotBefore:
+			message.NotBefore, err = parseOptionalTime(value)
+		case _fieldRequestID:
+			message.RequestID = value
+		default:
+			if message.URI == "" {
+				statement = append(statement, line)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s", ErrMalformedMessage, field)
+		}
+	}
+
+	if message.URI == "" || message.Version != "1" || message.Nonce == "" || message.IssuedAt.IsZero() {
+		return nil, fmt.Errorf("%w: missing required fields", ErrMalformedMessage)
+	}
+
+	message.Statement = strings.Join(statement, "\n")
+	return message, nil
+}
+
+// Validate checks the time bounds of the message at now.
+func (m *Message) Validate(now time.Time) error {
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return errors.New("siwe message expired")
+	}
+
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return errors.New("siwe message not yet valid")
+	}
+
+	return nil
+}
+
+// VerifySignature checks that the personal_sign signature over raw was
+// produced by the message address.
+func VerifySignature(raw string, message *Message, signature string) error {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return ErrInvalidSignature
+	}
+
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte(raw)), sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if crypto.PubkeyToAddress(*publicKey) != message.Address {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,155 @@
+package siwe
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testMessage(address common.Address, extra ...string) string {
+	lines := []string{
+		"example.com" + _headerSuffix,
+		address.Hex(),
+		"",
+		"Sign in to trade.",
+		"",
+		"URI: https://example.com/login",
+		"Version: 1",
+		"Chain ID: 8453",
+		"Nonce: abc123",
+		"Issued At: 2024-01-01T00:00:00Z",
+	}
+
+	return strings.Join(append(lines, extra...), "\n")
+}
+
+func signMessage(t *testing.T, key *ecdsa.PrivateKey, raw string) string {
+	t.Helper()
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(raw)), key)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig)
+}
+
+func TestParse(t *testing.T) {
+	address := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	message, err := Parse(testMessage(address, "Resources:", "- https://example.com/a"))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	switch {
+	case message.Domain != "example.com":
+		t.Fatalf("unexpected domain %q", message.Domain)
+	case message.Address != address:
+		t.Fatalf("unexpected address %s", message.Address)
+	case message.URI != "https://example.com/login":
+		t.Fatalf("unexpected uri %q", message.URI)
+	case message.ChainID != 8453:
+		t.Fatalf("unexpected chain id %d", message.ChainID)
+	case message.Statement != "Sign in to trade.":
+		t.Fatalf("unexpected statement %q", message.Statement)
+	case len(message.Resources) != 1:
+		t.Fatalf("unexpected resources %v", message.Resources)
+	}
+
+	tests := map[string]string{
+		"missing header":  strings.Join(strings.Split(testMessage(address), "\n")[1:], "\n"),
+		"invalid address": strings.Replace(testMessage(address), address.Hex(), "0xnope", 1),
+		"missing nonce":   strings.Replace(testMessage(address), "Nonce: abc123", "", 1),
+		"wrong version":   strings.Replace(testMessage(address), "Version: 1", "Version: 2", 1),
+		"invalid chain":   strings.Replace(testMessage(address), "Chain ID: 8453", "Chain ID: base", 1),
+		"invalid time":    testMessage(address, "Expiration Time: tomorrow"),
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(raw); !errors.Is(err, ErrMalformedMessage) {
+				t.Fatalf("expected a malformed message, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	address := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	tests := []struct {
+		name  string
+		extra []string
+		valid bool
+	}{
+		{name: "no bounds", valid: true},
+		{name: "before expiry", extra: []string{"Expiration Time: 2024-01-01T12:05:00Z"}, valid: true},
+		{name: "expired", extra: []string{"Expiration Time: 2024-01-01T11:55:00Z"}},
+		{name: "expires now", extra: []string{"Expiration Time: 2024-01-01T12:00:00Z"}},
+		{name: "after not before", extra: []string{"Not Before: 2024-01-01T11:55:00Z"}, valid: true},
+		{name: "not yet valid", extra: []string{"Not Before: 2024-01-01T12:05:00Z"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := Parse(testMessage(address, tt.extra...))
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+
+			if err = message.Validate(now); (err == nil) != tt.valid {
+				t.Fatalf("expected valid=%v, got %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	raw := testMessage(signer)
+	signature := signMessage(t, key, raw)
+
+	message, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if err = VerifySignature(raw, message, signature); err != nil {
+		t.Fatalf("expected a valid signature, got %v", err)
+	}
+
+	tests := map[string]struct {
+		raw       string
+		address   common.Address
+		signature string
+	}{
+		"tampered message": {raw: strings.Replace(raw, "abc123", "abc124", 1), address: signer, signature: signature},
+		"other signer":     {raw: raw, address: common.HexToAddress("0x0000000000000000000000000000000000000001"), signature: signature},
+		"short signature":  {raw: raw, address: signer, signature: signature[:20]},
+		"not hex":          {raw: raw, address: signer, signature: "signature"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifySignature(tt.raw, &Message{Address: tt.address}, tt.signature)
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("expected an invalid signature, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+type AuthRepo struct {
+	storage Storage
+}
+
+func NewAuthRepo(storage Storage) *AuthRepo {
+	return &AuthRepo{storage: storage}
+}
+
+func (a *AuthRepo) SaveNonce(ctx context.Context, owner common.Address, nonce string, expiry time.Duration) error {
+	return a.storage.Write(ctx, entity.KeySIWENonce(owner, nonce), owner.Hex(), expiry)
+}
+
+// ConsumeNonce removes nonce if it was issued to owner and has not expired,
+// so a signed message can only be exchanged for a session once.
+func (a *AuthRepo) ConsumeNonce(ctx context.Context, owner common.Address, nonce string) error {
+	_, err := a.storage.ReadAndDelete(ctx, entity.KeySIWENonce(owner, nonce))
+	return err
+}
@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_followLockExpiry = time.Second * 30
+)
+
+type FollowsRepo struct {
+	storage Storage
+}
+
+func NewFollowsRepo(storage Storage) *FollowsRepo {
+	return &FollowsRepo{storage: storage}
+}
+
+func (f *FollowsRepo) SaveFollow(ctx context.Context, follow *entity.Follow) error {
+	value, err := json.Marshal(follow)
+	if err != nil {
+		return err
+	}
+
+	key := entity.KeyFollow(common.HexToAddress(follow.Leader), common.HexToAddress(follow.Follower))
+	return f.storage.Write(ctx, key, string(value), 0)
+}
+
+func (f *FollowsRepo) Follow(ctx context.Context, leader common.Address, follower common.Address) (*entity.Follow, error) {
+	return f.read(ctx, entity.KeyFollow(leader, follower))
+}
+
+func (f *FollowsRepo) DeleteFollow(ctx context.Context, leader common.Address, follower common.Address) error {
+	return f.storage.Delete(ctx, entity.KeyFollow(leader, follower))
+}
+
+func (f *FollowsRepo) Followers(ctx context.Context, leader common.Address) ([]*entity.Follow, error) {
+	return f.scan(ctx, entity.KeyFollowerPattern(leader))
+}
+
+func (f *FollowsRepo) Following(ctx context.Context, follower common.Address) ([]*entity.Follow, error) {
+	return f.scan(ctx, entity.KeyFollowingPattern(follower))
+}
+
+func (f *FollowsRepo) Lock(ctx context.Context, leader common.Address, follower common.Address) (string, error) {
+	return acquireLock(ctx, f.storage, entity.KeyFollowLock(leader, follower), _followLockExpiry)
+}
+
+func (f *FollowsRepo) Unlock(ctx context.Context, leader common.Address, follower common.Address, token string) error {
+	return releaseLock(ctx, f.storage, entity.KeyFollowLock(leader, follower), token)
+}
+
+func (f *FollowsRepo) scan(ctx context.Context, match string) ([]*entity.Follow, error) {
+	follows := make([]*entity.Follow, 0)
+	err := scanKeys(ctx, f.storage, match, func(key string) error {
+		follow, err := f.read(ctx, key)
+		switch {
+		case err == nil:
+			follows = append(follows, follow)
+		case errors.Is(err, entity.ErrNoFollowFound):
+		default:
+			return err
+		}
+
+		return nil
+	})
+
+	return follows, err
+}
+
+func (f *FollowsRepo) read(ctx context.Context, key string) (*entity.Follow, error) {
+	value, err := f.storage.Read(ctx, key)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoFollowFound
+	default:
+		return nil, err
+	}
+
+	follow := &entity.Follow{}
+	if err = json.Unmarshal([]byte(value), follow); err != nil {
+		return nil, err
+	}
+
+	return follow, nil
+}
@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+type FrameActionsRepo struct {
+	storage Storage
+}
+
+func NewFrameActionsRepo(storage Storage) *FrameActionsRepo {
+	return &FrameActionsRepo{storage: storage}
+}
+
+// ClaimFrameAction records the message hash of a frame action, it reports
+// false when the message was already used.
+func (f *FrameActionsRepo) ClaimFrameAction(ctx context.Context, hash string, expiry time.Duration) (bool, error) {
+	return f.storage.WriteIfAbsent(ctx, entity.KeyFrameAction(hash), hash, expiry)
+}
@@ -8,4 +8,12 @@ import (
 type Storage interface {
 	Read(ctx context.Context, key string) (string, error)
 	Write(ctx context.Context, key string, data string, expiration time.Duration) error
+	WriteIfAbsent(ctx context.Context, key string, data string, expiration time.Duration) (bool, error)
+	ReadAndDelete(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, key string) error
+	DeleteIfEqual(ctx context.Context, key string, value string) (bool, error)
+	AddMember(ctx context.Context, key string, member string) error
+	RemoveMember(ctx context.Context, key string, member string) error
+	Members(ctx context.Context, key string) ([]string, error)
+	Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error)
 }
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_lockTokenLength = 16
+)
+
+// acquireLock takes the lock at key by writing a random token to it, the
+// token is returned to the holder who must hand it to releaseLock.
+func acquireLock(ctx context.Context, storage Storage, key string, expiry time.Duration) (string, error) {
+	raw := make([]byte, _lockTokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	token := hexutil.Encode(raw)
+	acquired, err := storage.WriteIfAbsent(ctx, key, token, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	if !acquired {
+		return "", entity.ErrLockNotAcquired
+	}
+
+	return token, nil
+}
+
+// releaseLock deletes the lock at key only while it still holds token, so a
+// holder whose lock expired never releases the lock of the next holder.
+func releaseLock(ctx context.Context, storage Storage, key string, token string) error {
+	_, err := storage.DeleteIfEqual(ctx, key, token)
+	return err
+}
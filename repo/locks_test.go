@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+)
+
+func TestExpiredHolderDoesNotReleaseNextLock(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: server.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	const key = "TEST_LOCK"
+	stale, err := acquireLock(ctx, storage, key, time.Second)
+	if err != nil {
+		t.Fatalf("failed to acquire lock: %v", err)
+	}
+
+	if _, err = acquireLock(ctx, storage, key, time.Second); !errors.Is(err, entity.ErrLockNotAcquired) {
+		t.Fatalf("expected a held lock to be refused, got %v", err)
+	}
+
+	server.FastForward(2 * time.Second)
+	current, err := acquireLock(ctx, storage, key, time.Second)
+	if err != nil {
+		t.Fatalf("failed to acquire expired lock: %v", err)
+	}
+
+	if err = releaseLock(ctx, storage, key, stale); err != nil {
+		t.Fatalf("failed to release stale lock: %v", err)
+	}
+
+	if value, _ := server.Get(key); value != current {
+		t.Fatalf("stale holder released the current lock, key holds %q", value)
+	}
+
+	if err = releaseLock(ctx, storage, key, current); err != nil {
+		t.Fatalf("failed to release lock: %v", err)
+	}
+
+	if server.Exists(key) {
+		t.Fatal("holder failed to release its own lock")
+	}
+}
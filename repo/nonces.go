@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_nonceLockExpiry = time.Second * 30
+)
+
+type NoncesRepo struct {
+	storage Storage
+}
+
+func NewNoncesRepo(storage Storage) *NoncesRepo {
+	return &NoncesRepo{storage: storage}
+}
+
+func (n *NoncesRepo) Lock(ctx context.Context, signer common.Address) (string, error) {
+	return acquireLock(ctx, n.storage, entity.KeyNonceLock(signer), _nonceLockExpiry)
+}
+
+func (n *NoncesRepo) Unlock(ctx context.Context, signer common.Address, token string) error {
+	return releaseLock(ctx, n.storage, entity.KeyNonceLock(signer), token)
+}
+
+func (n *NoncesRepo) NonceState(ctx context.Context, signer common.Address) (*entity.NonceState, error) {
+	value, err := n.storage.Read(ctx, entity.KeyNonce(signer))
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return &entity.NonceState{Signer: signer.Hex()}, nil
+	default:
+		return nil, err
+	}
+
+	state := &entity.NonceState{}
+	if err = json.Unmarshal([]byte(value), state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (n *NoncesRepo) UpdateNonceState(ctx context.Context, signer common.Address, state *entity.NonceState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return n.storage.Write(ctx, entity.KeyNonce(signer), string(value), 0)
+}
@@ -0,0 +1,188 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_orderClaimExpiry = time.Minute * 10
+)
+
+type OrdersRepo struct {
+	storage Storage
+}
+
+func NewOrdersRepo(storage Storage) *OrdersRepo {
+	return &OrdersRepo{storage: storage}
+}
+
+func (o *OrdersRepo) SaveOrder(ctx context.Context, order *entity.LimitOrder, expiry time.Duration) error {
+	value, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+
+	key := entity.KeyOrder(common.HexToAddress(order.Owner), order.ID)
+	if err = o.storage.Write(ctx, key, string(value), expiry); err != nil {
+		return err
+	}
+
+	return o.index(ctx, order, key)
+}
+
+func (o *OrdersRepo) Order(ctx context.Context, owner common.Address, id string) (*entity.LimitOrder, error) {
+	return o.read(ctx, entity.KeyOrder(owner, id))
+}
+
+func (o *OrdersRepo) Orders(ctx context.Context, owner common.Address) ([]*entity.LimitOrder, error) {
+	return o.scan(ctx, entity.KeyOwnerOrderPattern(owner))
+}
+
+// OrderTokens lists the tokens that have open orders.
+func (o *OrdersRepo) OrderTokens(ctx context.Context) ([]common.Address, error) {
+	members, err := o.storage.Members(ctx, entity.KeyOrderTokens())
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]common.Address, 0, len(members))
+	for _, member := range members {
+		tokens = append(tokens, common.HexToAddress(member))
+	}
+
+	return tokens, nil
+}
+
+// OpenOrders reads the open orders of token from its index, dropping the
+// entries of orders that have since closed.
+func (o *OrdersRepo) OpenOrders(ctx context.Context, token common.Address) ([]*entity.LimitOrder, error) {
+	index := entity.KeyTokenOrders(token)
+	keys, err := o.storage.Members(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*entity.LimitOrder, 0, len(keys))
+	for _, key := range keys {
+		order, err := o.read(ctx, key)
+		switch {
+		case err == nil && order.Status == entity.OrderStatusOpen:
+			orders = append(orders, order)
+			continue
+		case err == nil, errors.Is(err, entity.ErrNoOrderFound):
+		default:
+			return nil, err
+		}
+
+		if err = o.storage.RemoveMember(ctx, index, key); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(orders) == 0 {
+		return orders, o.dropToken(ctx, token)
+	}
+
+	return orders, nil
+}
+
+// IndexOrders adds every open order to the token index, covering orders
+// saved before the index existed.
+func (o *OrdersRepo) IndexOrders(ctx context.Context) error {
+	orders, err := o.scan(ctx, entity.KeyOrderPattern())
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if err = o.index(ctx, order, entity.KeyOrder(common.HexToAddress(order.Owner), order.ID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ClaimOrder reports whether the caller is the first to claim id, so an
+// order is only ever triggered once.
+func (o *OrdersRepo) ClaimOrder(ctx context.Context, id string) (bool, error) {
+	return o.storage.WriteIfAbsent(ctx, entity.KeyOrderClaim(id), id, _orderClaimExpiry)
+}
+
+// ReleaseOrder drops the claim on id, leaving the order to be triggered or
+// cancelled again.
+func (o *OrdersRepo) ReleaseOrder(ctx context.Context, id string) error {
+	return o.storage.Delete(ctx, entity.KeyOrderClaim(id))
+}
+
+// index keeps open orders in a set per token, so the price watcher reads only
+// the orders of the tokens it polls.
+func (o *OrdersRepo) index(ctx context.Context, order *entity.LimitOrder, key string) error {
+	token := common.HexToAddress(order.Token)
+	if order.Status != entity.OrderStatusOpen {
+		return o.storage.RemoveMember(ctx, entity.KeyTokenOrders(token), key)
+	}
+
+	if err := o.storage.AddMember(ctx, entity.KeyTokenOrders(token), key); err != nil {
+		return err
+	}
+
+	return o.storage.AddMember(ctx, entity.KeyOrderTokens(), token.Hex())
+}
+
+// dropToken removes token from the polled tokens, putting it back when an
+// order was indexed for it meanwhile.
+func (o *OrdersRepo) dropToken(ctx context.Context, token common.Address) error {
+	if err := o.storage.RemoveMember(ctx, entity.KeyOrderTokens(), token.Hex()); err != nil {
+		return err
+	}
+
+	keys, err := o.storage.Members(ctx, entity.KeyTokenOrders(token))
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+
+	return o.storage.AddMember(ctx, entity.KeyOrderTokens(), token.Hex())
+}
+
+func (o *OrdersRepo) scan(ctx context.Context, match string) ([]*entity.LimitOrder, error) {
+	orders := make([]*entity.LimitOrder, 0)
+	err := scanKeys(ctx, o.storage, match, func(key string) error {
+		order, err := o.read(ctx, key)
+		switch {
+		case err == nil:
+			orders = append(orders, order)
+		case errors.Is(err, entity.ErrNoOrderFound):
+		default:
+			return err
+		}
+
+		return nil
+	})
+
+	return orders, err
+}
+
+func (o *OrdersRepo) read(ctx context.Context, key string) (*entity.LimitOrder, error) {
+	value, err := o.storage.Read(ctx, key)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoOrderFound
+	default:
+		return nil, err
+	}
+
+	order := &entity.LimitOrder{}
+	if err = json.Unmarshal([]byte(value), order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+)
+
+func TestOpenOrdersFollowTokenIndex(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: server.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	orders := NewOrdersRepo(storage)
+	token := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	order := &entity.LimitOrder{
+		ID:     "order",
+		Owner:  common.HexToAddress("0x0000000000000000000000000000000000000002").Hex(),
+		Token:  token.Hex(),
+		Status: entity.OrderStatusOpen,
+	}
+
+	if err = orders.SaveOrder(ctx, order, 0); err != nil {
+		t.Fatalf("failed to save order: %v", err)
+	}
+
+	open, err := orders.OpenOrders(ctx, token)
+	if err != nil || len(open) != 1 {
+		t.Fatalf("expected one open order, got %d, %v", len(open), err)
+	}
+
+	order.Status = entity.OrderStatusTriggered
+	if err = orders.SaveOrder(ctx, order, 0); err != nil {
+		t.Fatalf("failed to save order: %v", err)
+	}
+
+	if open, err = orders.OpenOrders(ctx, token); err != nil || len(open) != 0 {
+		t.Fatalf("expected no open orders, got %d, %v", len(open), err)
+	}
+
+	tokens, err := orders.OrderTokens(ctx)
+	if err != nil || len(tokens) != 0 {
+		t.Fatalf("expected the token to leave the index, got %v, %v", tokens, err)
+	}
+}
+
+func TestReleaseOrderAllowsReclaim(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: server.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	orders := NewOrdersRepo(storage)
+	if claimed, err := orders.ClaimOrder(ctx, "order"); err != nil || !claimed {
+		t.Fatalf("expected the first claim to win, got %v, %v", claimed, err)
+	}
+
+	if claimed, err := orders.ClaimOrder(ctx, "order"); err != nil || claimed {
+		t.Fatalf("expected a second claim to lose, got %v, %v", claimed, err)
+	}
+
+	if err = orders.ReleaseOrder(ctx, "order"); err != nil {
+		t.Fatalf("failed to release order: %v", err)
+	}
+
+	if claimed, err := orders.ClaimOrder(ctx, "order"); err != nil || !claimed {
+		t.Fatalf("expected a released order to be claimable, got %v, %v", claimed, err)
+	}
+}
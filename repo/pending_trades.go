@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_pendingTradeLockExpiry = time.Minute
+)
+
+type PendingTradesRepo struct {
+	storage Storage
+}
+
+func NewPendingTradesRepo(storage Storage) *PendingTradesRepo {
+	return &PendingTradesRepo{storage: storage}
+}
+
+func (p *PendingTradesRepo) SavePendingTrade(ctx context.Context, pending *entity.PendingTrade, expiry time.Duration) error {
+	value, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+
+	return p.storage.Write(ctx, entity.KeyPendingTrade(common.HexToAddress(pending.Owner), pending.ID), string(value), expiry)
+}
+
+func (p *PendingTradesRepo) PendingTrade(ctx context.Context, owner common.Address, id string) (*entity.PendingTrade, error) {
+	return p.read(ctx, entity.KeyPendingTrade(owner, id))
+}
+
+// PendingTrades lists every pending trade of every owner.
+func (p *PendingTradesRepo) PendingTrades(ctx context.Context) ([]*entity.PendingTrade, error) {
+	trades := make([]*entity.PendingTrade, 0)
+	err := scanKeys(ctx, p.storage, entity.KeyPendingTradePattern(), func(key string) error {
+		pending, err := p.read(ctx, key)
+		switch {
+		case err == nil:
+			trades = append(trades, pending)
+		case errors.Is(err, entity.ErrNoPendingTrade):
+		default:
+			return err
+		}
+
+		return nil
+	})
+
+	return trades, err
+}
+
+func (p *PendingTradesRepo) read(ctx context.Context, key string) (*entity.PendingTrade, error) {
+	value, err := p.storage.Read(ctx, key)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoPendingTrade
+	default:
+		return nil, err
+	}
+
+	pending := &entity.PendingTrade{}
+	if err = json.Unmarshal([]byte(value), pending); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+func (p *PendingTradesRepo) DeletePendingTrade(ctx context.Context, owner common.Address, id string) error {
+	return p.storage.Delete(ctx, entity.KeyPendingTrade(owner, id))
+}
+
+// Lock serialises replacements of a pending trade with its resolution.
+func (p *PendingTradesRepo) Lock(ctx context.Context, owner common.Address, id string) (string, error) {
+	return acquireLock(ctx, p.storage, entity.KeyPendingTradeLock(owner, id), _pendingTradeLockExpiry)
+}
+
+func (p *PendingTradesRepo) Unlock(ctx context.Context, owner common.Address, id string, token string) error {
+	return releaseLock(ctx, p.storage, entity.KeyPendingTradeLock(owner, id), token)
+}
@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_positionClaimExpiry = time.Minute * 10
+)
+
+type PositionsRepo struct {
+	storage Storage
+}
+
+func NewPositionsRepo(storage Storage) *PositionsRepo {
+	return &PositionsRepo{storage: storage}
+}
+
+func (p *PositionsRepo) SavePosition(ctx context.Context, position *entity.Position, expiry time.Duration) error {
+	value, err := json.Marshal(position)
+	if err != nil {
+		return err
+	}
+
+	return p.storage.Write(ctx, entity.KeyPosition(common.HexToAddress(position.Owner), position.ID), string(value), expiry)
+}
+
+func (p *PositionsRepo) Position(ctx context.Context, owner common.Address, id string) (*entity.Position, error) {
+	return p.read(ctx, entity.KeyPosition(owner, id))
+}
+
+func (p *PositionsRepo) Positions(ctx context.Context, owner common.Address) ([]*entity.Position, error) {
+	return p.scan(ctx, entity.KeyOwnerPositionPattern(owner))
+}
+
+func (p *PositionsRepo) AllPositions(ctx context.Context) ([]*entity.Position, error) {
+	return p.scan(ctx, entity.KeyPositionPattern())
+}
+
+// ClaimPosition reports whether the caller is the first to claim id, so a
+// position is only ever sold once.
+func (p *PositionsRepo) ClaimPosition(ctx context.Context, id string) (bool, error) {
+	return p.storage.WriteIfAbsent(ctx, entity.KeyPositionClaim(id), id, _positionClaimExpiry)
+}
+
+// ReleasePosition drops the claim on id, leaving the position to be
+// triggered again.
+func (p *PositionsRepo) ReleasePosition(ctx context.Context, id string) error {
+	return p.storage.Delete(ctx, entity.KeyPositionClaim(id))
+}
+
+func (p *PositionsRepo) scan(ctx context.Context, match string) ([]*entity.Position, error) {
+	positions := make([]*entity.Position, 0)
+	err := scanKeys(ctx, p.storage, match, func(key string) error {
+		position, err := p.read(ctx, key)
+		switch {
+		case err == nil:
+			positions = append(positions, position)
+		case errors.Is(err, entity.ErrNoPositionFound):
+		default:
+			return err
+		}
+
+		return nil
+	})
+
+	return positions, err
+}
+
+func (p *PositionsRepo) read(ctx context.Context, key string) (*entity.Position, error) {
+	value, err := p.storage.Read(ctx, key)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoPositionFound
+	default:
+		return nil, err
+	}
+
+	position := &entity.Position{}
+	if err = json.Unmarshal([]byte(value), position); err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
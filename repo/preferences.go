@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+type PreferencesRepo struct {
+	storage Storage
+}
+
+func NewPreferencesRepo(storage Storage) *PreferencesRepo {
+	return &PreferencesRepo{storage: storage}
+}
+
+func (p *PreferencesRepo) SavePreferences(ctx context.Context, preferences *entity.Preferences) error {
+	value, err := json.Marshal(preferences)
+	if err != nil {
+		return err
+	}
+
+	return p.storage.Write(ctx, entity.KeyPreferences(common.HexToAddress(preferences.Owner)), string(value), 0)
+}
+
+func (p *PreferencesRepo) Preferences(ctx context.Context, owner common.Address) (*entity.Preferences, error) {
+	value, err := p.storage.Read(ctx, entity.KeyPreferences(owner))
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoPreferencesFound
+	default:
+		return nil, err
+	}
+
+	preferences := &entity.Preferences{}
+	if err = json.Unmarshal([]byte(value), preferences); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+type QuotesRepo struct {
+	storage Storage
+}
+
+func NewQuotesRepo(storage Storage) *QuotesRepo {
+	return &QuotesRepo{storage: storage}
+}
+
+func (q *QuotesRepo) SaveQuoteTerms(ctx context.Context, terms *entity.QuoteTerms, expiry time.Duration) error {
+	value, err := json.Marshal(terms)
+	if err != nil {
+		return err
+	}
+
+	return q.storage.Write(ctx, entity.KeyQuote(terms.ID), string(value), expiry)
+}
+
+func (q *QuotesRepo) QuoteTerms(ctx context.Context, id string) (*entity.QuoteTerms, error) {
+	value, err := q.storage.Read(ctx, entity.KeyQuote(id))
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoQuoteTerms
+	default:
+		return nil, err
+	}
+
+	terms := &entity.QuoteTerms{}
+	if err = json.Unmarshal([]byte(value), terms); err != nil {
+		return nil, err
+	}
+
+	return terms, nil
+}
+
+// ConsumeQuoteTerms removes the terms for id, only one of several concurrent
+// confirmations succeeds so a quote is traded once.
+func (q *QuotesRepo) ConsumeQuoteTerms(ctx context.Context, id string) error {
+	_, err := q.storage.ReadAndDelete(ctx, entity.KeyQuote(id))
+	if errors.Is(err, entity.ErrEmpty) {
+		return entity.ErrNoQuoteTerms
+	}
+
+	return err
+}
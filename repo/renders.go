@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+type RendersRepo struct {
+	storage Storage
+}
+
+func NewRendersRepo(storage Storage) *RendersRepo {
+	return &RendersRepo{storage: storage}
+}
+
+func (r *RendersRepo) Render(ctx context.Context, hash string) ([]byte, error) {
+	value, err := r.storage.Read(ctx, entity.KeyRender(hash))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(value), nil
+}
+
+func (r *RendersRepo) SaveRender(ctx context.Context, hash string, png []byte, expiry time.Duration) error {
+	return r.storage.Write(ctx, entity.KeyRender(hash), string(png), expiry)
+}
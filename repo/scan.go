@@ -0,0 +1,30 @@
+package repo
+
+import "context"
+
+const (
+	_scanBatchSize = 100
+)
+
+// scanKeys visits every key matching match, keys written during the scan
+// may or may not be visited.
+func scanKeys(ctx context.Context, storage Storage, match string, visit func(key string) error) error {
+	cursor := uint64(0)
+	for {
+		keys, next, err := storage.Scan(ctx, cursor, match, _scanBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err = visit(key); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_scheduleLockExpiry = time.Minute
+)
+
+type SchedulesRepo struct {
+	storage Storage
+}
+
+func NewSchedulesRepo(storage Storage) *SchedulesRepo {
+	return &SchedulesRepo{storage: storage}
+}
+
+func (s *SchedulesRepo) SaveSchedule(ctx context.Context, schedule *entity.Schedule, expiry time.Duration) error {
+	value, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Write(ctx, entity.KeySchedule(common.HexToAddress(schedule.Owner), schedule.ID), string(value), expiry)
+}
+
+func (s *SchedulesRepo) Schedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error) {
+	return s.read(ctx, entity.KeySchedule(owner, id))
+}
+
+func (s *SchedulesRepo) Schedules(ctx context.Context, owner common.Address) ([]*entity.Schedule, error) {
+	return s.scan(ctx, entity.KeyOwnerSchedulePattern(owner))
+}
+
+func (s *SchedulesRepo) AllSchedules(ctx context.Context) ([]*entity.Schedule, error) {
+	return s.scan(ctx, entity.KeySchedulePattern())
+}
+
+// Lock serialises updates to a schedule between the scheduler and the owner
+// endpoints.
+func (s *SchedulesRepo) Lock(ctx context.Context, id string) (string, error) {
+	return acquireLock(ctx, s.storage, entity.KeyScheduleLock(id), _scheduleLockExpiry)
+}
+
+func (s *SchedulesRepo) Unlock(ctx context.Context, id string, token string) error {
+	return releaseLock(ctx, s.storage, entity.KeyScheduleLock(id), token)
+}
+
+func (s *SchedulesRepo) scan(ctx context.Context, match string) ([]*entity.Schedule, error) {
+	schedules := make([]*entity.Schedule, 0)
+	err := scanKeys(ctx, s.storage, match, func(key string) error {
+		schedule, err := s.read(ctx, key)
+		switch {
+		case err == nil:
+			schedules = append(schedules, schedule)
+		case errors.Is(err, entity.ErrNoScheduleFound):
+		default:
+			return err
+		}
+
+		return nil
+	})
+
+	return schedules, err
+}
+
+func (s *SchedulesRepo) read(ctx context.Context, key string) (*entity.Schedule, error) {
+	value, err := s.storage.Read(ctx, key)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoScheduleFound
+	default:
+		return nil, err
+	}
+
+	schedule := &entity.Schedule{}
+	if err = json.Unmarshal([]byte(value), schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
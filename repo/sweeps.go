@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_sweepLockExpiry = time.Minute * 10
+)
+
+type SweepsRepo struct {
+	storage Storage
+}
+
+func NewSweepsRepo(storage Storage) *SweepsRepo {
+	return &SweepsRepo{storage: storage}
+}
+
+func (s *SweepsRepo) SaveSweep(ctx context.Context, sweep *entity.Sweep, expiry time.Duration) error {
+	value, err := json.Marshal(sweep)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Write(ctx, entity.KeySweep(common.HexToAddress(sweep.Owner), sweep.ID), string(value), expiry)
+}
+
+func (s *SweepsRepo) Sweep(ctx context.Context, owner common.Address, id string) (*entity.Sweep, error) {
+	value, err := s.storage.Read(ctx, entity.KeySweep(owner, id))
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoSweepFound
+	default:
+		return nil, err
+	}
+
+	sweep := &entity.Sweep{}
+	if err = json.Unmarshal([]byte(value), sweep); err != nil {
+		return nil, err
+	}
+
+	return sweep, nil
+}
+
+// Lock keeps a single sweep per owner running, the lock expires on its own
+// should the sweeping instance die.
+func (s *SweepsRepo) Lock(ctx context.Context, owner common.Address) (string, error) {
+	token, err := acquireLock(ctx, s.storage, entity.KeySweepLock(owner), _sweepLockExpiry)
+	if errors.Is(err, entity.ErrLockNotAcquired) {
+		return "", entity.ErrSweepInProgress
+	}
+
+	return token, err
+}
+
+func (s *SweepsRepo) Unlock(ctx context.Context, owner common.Address, token string) error {
+	return releaseLock(ctx, s.storage, entity.KeySweepLock(owner), token)
+}
+
+func (s *SweepsRepo) TrackToken(ctx context.Context, owner common.Address, token common.Address) error {
+	return s.storage.Write(ctx, entity.KeyTradedToken(owner, token), token.Hex(), 0)
+}
+
+// Owners returns every owner whose trading account traded a token.
+func (s *SweepsRepo) Owners(ctx context.Context) ([]common.Address, error) {
+	owners := make([]common.Address, 0)
+	err := scanKeys(ctx, s.storage, entity.KeyAllTradedTokensPattern(), func(key string) error {
+		parts := strings.Split(key, ":")
+		if len(parts) != 3 || !common.IsHexAddress(parts[1]) {
+			return nil
+		}
+
+		if owner := common.HexToAddress(parts[1]); !slices.Contains(owners, owner) {
+			owners = append(owners, owner)
+		}
+
+		return nil
+	})
+
+	return owners, err
+}
+
+// Tokens returns every token the owner's trading account traded.
+func (s *SweepsRepo) Tokens(ctx context.Context, owner common.Address) ([]common.Address, error) {
+	tokens := make([]common.Address, 0)
+	err := scanKeys(ctx, s.storage, entity.KeyTradedTokenPattern(owner), func(key string) error {
+		value, err := s.storage.Read(ctx, key)
+		switch {
+		case err == nil:
+			tokens = append(tokens, common.HexToAddress(value))
+		case errors.Is(err, entity.ErrEmpty):
+		default:
+			return err
+		}
+
+		return nil
+	})
+
+	return tokens, err
+}
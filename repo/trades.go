@@ -11,7 +11,8 @@ import (
 )
 
 const (
-	_tradeExpiry = time.Minute * 10
+	_tradeExpiry    = time.Minute * 10
+	_jobTradeExpiry = time.Hour * 24 * 7
 )
 
 type TradesRepo struct {
@@ -40,11 +41,48 @@ func (t *TradesRepo) LatestTrade(ctx context.Context, owner common.Address) (*en
 	return trade, nil
 }
 
+// UpdateTrade records trade as the owner's latest, trades of jobs with an ID
+// are also kept under that ID for longer.
 func (t *TradesRepo) UpdateTrade(ctx context.Context, owner common.Address, trade *entity.Trade) error {
 	value, err := json.Marshal(trade)
 	if err != nil {
 		return err
 	}
 
+	if trade.JobID != "" {
+		if err = t.storage.Write(ctx, entity.KeyTrade(trade.JobID), string(value), _jobTradeExpiry); err != nil {
+			return err
+		}
+	}
+
 	return t.storage.Write(ctx, entity.KeyTrades(owner), string(value), _tradeExpiry)
 }
+
+// SaveTrade keeps trade under its job ID only, leaving the owner's latest
+// trade untouched.
+func (t *TradesRepo) SaveTrade(ctx context.Context, trade *entity.Trade) error {
+	value, err := json.Marshal(trade)
+	if err != nil {
+		return err
+	}
+
+	return t.storage.Write(ctx, entity.KeyTrade(trade.JobID), string(value), _jobTradeExpiry)
+}
+
+func (t *TradesRepo) Trade(ctx context.Context, jobID string) (*entity.Trade, error) {
+	value, err := t.storage.Read(ctx, entity.KeyTrade(jobID))
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, entity.ErrNoTradesFound
+	default:
+		return nil, err
+	}
+
+	trade := &entity.Trade{}
+	if err = json.Unmarshal([]byte(value), trade); err != nil {
+		return nil, err
+	}
+
+	return trade, nil
+}
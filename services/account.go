@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -19,6 +20,7 @@ type AccountService struct {
 	keyManager keyManager
 	processor  tradeProcessor
 	repo       tradesRepo
+	limits     TradeLimits
 }
 
 func NewAccountService(
@@ -26,12 +28,14 @@ func NewAccountService(
 	repo tradesRepo,
 	processor tradeProcessor,
 	backend *ethclient.Client,
+	limits TradeLimits,
 ) *AccountService {
 	return &AccountService{
 		keyManager: manager,
 		repo:       repo,
 		processor:  processor,
 		backend:    backend,
+		limits:     limits,
 	}
 }
 
@@ -44,6 +48,29 @@ func (a *AccountService) GetTradingAccount(
 		return nil, err
 	}
 
+	return a.tradingAccount(ctx, address, account)
+}
+
+// FindTradingAccount is GetTradingAccount for owners that already have a
+// trading account, entity.ErrNoAccountFound is returned instead of
+// provisioning one.
+func (a *AccountService) FindTradingAccount(
+	ctx context.Context,
+	address common.Address,
+) (*entity.TradingAccount, error) {
+	account, err := a.keyManager.LookupSigningAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.tradingAccount(ctx, address, account)
+}
+
+func (a *AccountService) tradingAccount(
+	ctx context.Context,
+	address common.Address,
+	account common.Address,
+) (*entity.TradingAccount, error) {
 	resp := &entity.TradingAccount{
 		Owner:   address.Hex(),
 		Account: account.Hex(),
@@ -65,10 +92,17 @@ func (a *AccountService) PlaceTradeRequest(
 	tokenAddress common.Address,
 	ethIn string,
 ) error {
+	controls, err := a.limits.Apply(entity.TradeControls{}, time.Now())
+	if err != nil {
+		return err
+	}
+
 	return a.processor.Submit(ctx, &entity.TradeRequest{
-		Owner:   address.Hex(),
-		EthIn:   ethIn,
-		ToToken: tokenAddress.Hex(),
+		Owner:         address.Hex(),
+		SellToken:     entity.NativeToken.Hex(),
+		BuyToken:      tokenAddress.Hex(),
+		AmountIn:      ethIn,
+		TradeControls: controls,
 	})
 }
 
@@ -78,3 +112,53 @@ func (a *AccountService) LatestTrade(
 ) (*entity.Trade, error) {
 	return a.repo.LatestTrade(ctx, address)
 }
+
+// SpeedUpTrade re-signs the owner's pending trade with higher fees, txnHash
+// is the hash the trade was first submitted with.
+func (a *AccountService) SpeedUpTrade(
+	ctx context.Context,
+	address common.Address,
+	txnHash common.Hash,
+) (*entity.Trade, error) {
+	return a.processor.Replace(ctx, address, txnHash.Hex(), entity.TradeTxSpeedUp)
+}
+
+// CancelTrade replaces the owner's pending trade with a transfer of nothing
+// to the trading account at the same nonce.
+func (a *AccountService) CancelTrade(
+	ctx context.Context,
+	address common.Address,
+	txnHash common.Hash,
+) (*entity.Trade, error) {
+	return a.processor.Replace(ctx, address, txnHash.Hex(), entity.TradeTxCancel)
+}
+
+// Withdraw queues sending amount wei, or entity.WithdrawMax, of the trading
+// account's ETH to the owner.
+func (a *AccountService) Withdraw(ctx context.Context, address common.Address, amount string) error {
+	if amount != entity.WithdrawMax {
+		value, ok := new(big.Int).SetString(amount, 10)
+		if !ok || value.Sign() <= 0 {
+			return entity.ErrInvalidWithdrawal
+		}
+
+		account, err := a.keyManager.SigningAddress(ctx, address)
+		if err != nil {
+			return err
+		}
+
+		balance, err := a.backend.BalanceAt(ctx, account, nil)
+		if err != nil {
+			return err
+		}
+
+		if value.Cmp(balance) >= 0 {
+			return entity.ErrNothingToWithdraw
+		}
+	}
+
+	return a.processor.Withdraw(ctx, &entity.WithdrawRequest{
+		Owner:  address.Hex(),
+		Amount: amount,
+	})
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/session"
+	"github.com/rahul0tripathi/framecoiner/pkg/siwe"
+)
+
+const (
+	_siweNonceExpiry = time.Minute * 5
+	_siweNonceSize   = 16
+)
+
+type AuthService struct {
+	repo    authRepo
+	issuer  *session.Issuer
+	domain  string
+	chainID int64
+}
+
+func NewAuthService(repo authRepo, issuer *session.Issuer, domain string, chainID int64) *AuthService {
+	return &AuthService{
+		repo:    repo,
+		issuer:  issuer,
+		domain:  domain,
+		chainID: chainID,
+	}
+}
+
+func (a *AuthService) Nonce(ctx context.Context, owner common.Address) (string, error) {
+	raw := make([]byte, _siweNonceSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	nonce := hex.EncodeToString(raw)
+	if err := a.repo.SaveNonce(ctx, owner, nonce, _siweNonceExpiry); err != nil {
+		return "", err
+	}
+
+	return nonce, nil
+}
+
+func (a *AuthService) Verify(ctx context.Context, message string, signature string) (*entity.Session, error) {
+	parsed, err := siwe.Parse(message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnauthorized, err.Error())
+	}
+
+	uri, err := url.Parse(parsed.URI)
+	switch {
+	case parsed.Domain != a.domain:
+		return nil, fmt.Errorf("%w: domain mismatch", entity.ErrUnauthorized)
+	case err != nil || uri.Host != a.domain:
+		return nil, fmt.Errorf("%w: uri mismatch", entity.ErrUnauthorized)
+	case parsed.ChainID != a.chainID:
+		return nil, fmt.Errorf("%w: chain id mismatch", entity.ErrUnauthorized)
+	}
+
+	if err = parsed.Validate(time.Now()); err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnauthorized, err.Error())
+	}
+
+	if err = siwe.VerifySignature(message, parsed, signature); err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnauthorized, err.Error())
+	}
+
+	err = a.repo.ConsumeNonce(ctx, parsed.Address, parsed.Nonce)
+	switch {
+	case errors.Is(err, entity.ErrEmpty):
+		return nil, fmt.Errorf("%w: unknown or expired nonce", entity.ErrUnauthorized)
+	case err != nil:
+		return nil, err
+	}
+
+	token, expiresAt, err := a.issuer.Issue(parsed.Address.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Session{
+		Owner:     parsed.Address.Hex(),
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (a *AuthService) Authenticate(ctx context.Context, token string) (common.Address, error) {
+	claims, err := a.issuer.Verify(token)
+	if err != nil {
+		return common.HexToAddress(""), fmt.Errorf("%w: %s", entity.ErrUnauthorized, err.Error())
+	}
+
+	if !common.IsHexAddress(claims.Subject) {
+		return common.HexToAddress(""), entity.ErrUnauthorized
+	}
+
+	return common.HexToAddress(claims.Subject), nil
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+	"github.com/rahul0tripathi/framecoiner/pkg/session"
+	"github.com/rahul0tripathi/framecoiner/repo"
+)
+
+const (
+	_testDomain  = "app.example.com"
+	_testChainID = 8453
+)
+
+type siweFields struct {
+	domain    string
+	uri       string
+	chainID   int64
+	nonce     string
+	expiresAt time.Time
+	notBefore time.Time
+}
+
+func newTestAuthService(t *testing.T, ttl time.Duration) *AuthService {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: server.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	issuer, err := session.NewIssuer("0123456789abcdef0123456789abcdef", ttl)
+	if err != nil {
+		t.Fatalf("failed to create issuer: %v", err)
+	}
+
+	return NewAuthService(repo.NewAuthRepo(storage), issuer, _testDomain, _testChainID)
+}
+
+func signSIWE(t *testing.T, key *ecdsa.PrivateKey, fields siweFields) (string, string) {
+	t.Helper()
+
+	message := fmt.Sprintf(
+		"%s wants you to sign in with your Ethereum account:\n%s\n\nSign in.\n\nURI: %s\nVersion: 1\nChain ID: %d\nNonce: %s\nIssued At: %s",
+		fields.domain,
+		crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		fields.uri,
+		fields.chainID,
+		fields.nonce,
+		time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+	)
+	if !fields.expiresAt.IsZero() {
+		message += "\nExpiration Time: " + fields.expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	if !fields.notBefore.IsZero() {
+		message += "\nNot Before: " + fields.notBefore.UTC().Format(time.RFC3339)
+	}
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	return message, hexutil.Encode(sig)
+}
+
+func TestAuthVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(fields *siweFields)
+		valid  bool
+	}{
+		{name: "valid", modify: func(fields *siweFields) {}, valid: true},
+		{name: "domain mismatch", modify: func(fields *siweFields) { fields.domain = "evil.example.com" }},
+		{name: "uri mismatch", modify: func(fields *siweFields) { fields.uri = "https://evil.example.com/login" }},
+		{name: "invalid uri", modify: func(fields *siweFields) { fields.uri = "://" }},
+		{name: "chain mismatch", modify: func(fields *siweFields) { fields.chainID = 1 }},
+		{name: "expired", modify: func(fields *siweFields) { fields.expiresAt = time.Now().Add(-time.Minute) }},
+		{name: "not yet valid", modify: func(fields *siweFields) { fields.notBefore = time.Now().Add(time.Hour) }},
+		{name: "unknown nonce", modify: func(fields *siweFields) { fields.nonce = "unknown" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestAuthService(t, time.Hour)
+
+			key, err := crypto.GenerateKey()
+			if err != nil {
+				t.Fatalf("failed to generate key: %v", err)
+			}
+
+			owner := crypto.PubkeyToAddress(key.PublicKey)
+			nonce, err := svc.Nonce(ctx, owner)
+			if err != nil {
+				t.Fatalf("failed to issue nonce: %v", err)
+			}
+
+			fields := siweFields{
+				domain:  _testDomain,
+				uri:     "https://" + _testDomain + "/login",
+				chainID: _testChainID,
+				nonce:   nonce,
+			}
+			tt.modify(&fields)
+
+			message, signature := signSIWE(t, key, fields)
+			sess, err := svc.Verify(ctx, message, signature)
+			if !tt.valid {
+				if !errors.Is(err, entity.ErrUnauthorized) {
+					t.Fatalf("expected unauthorized, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to verify: %v", err)
+			}
+
+			authenticated, err := svc.Authenticate(ctx, sess.Token)
+			if err != nil || authenticated != owner {
+				t.Fatalf("expected the session to authenticate %s, got %s, %v", owner, authenticated, err)
+			}
+		})
+	}
+}
+
+func TestAuthVerifyRejectsNonceReuse(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestAuthService(t, time.Hour)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	nonce, err := svc.Nonce(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		t.Fatalf("failed to issue nonce: %v", err)
+	}
+
+	message, signature := signSIWE(t, key, siweFields{
+		domain:  _testDomain,
+		uri:     "https://" + _testDomain,
+		chainID: _testChainID,
+		nonce:   nonce,
+	})
+
+	if _, err = svc.Verify(ctx, message, signature); err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	if _, err = svc.Verify(ctx, message, signature); !errors.Is(err, entity.ErrUnauthorized) {
+		t.Fatalf("expected a replayed message to be rejected, got %v", err)
+	}
+}
+
+func TestAuthVerifyRejectsOtherSignersNonce(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestAuthService(t, time.Hour)
+
+	owner, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	attacker, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	nonce, err := svc.Nonce(ctx, crypto.PubkeyToAddress(owner.PublicKey))
+	if err != nil {
+		t.Fatalf("failed to issue nonce: %v", err)
+	}
+
+	message, signature := signSIWE(t, attacker, siweFields{
+		domain:  _testDomain,
+		uri:     "https://" + _testDomain,
+		chainID: _testChainID,
+		nonce:   nonce,
+	})
+
+	if _, err = svc.Verify(ctx, message, signature); !errors.Is(err, entity.ErrUnauthorized) {
+		t.Fatalf("expected another owner's nonce to be rejected, got %v", err)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestAuthService(t, time.Hour)
+	owner := "0x0000000000000000000000000000000000000001"
+
+	forger, err := session.NewIssuer("abcdef0123456789abcdef0123456789", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create issuer: %v", err)
+	}
+
+	expired, err := session.NewIssuer("0123456789abcdef0123456789abcdef", -time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create issuer: %v", err)
+	}
+
+	issue := func(issuer *session.Issuer, subject string) string {
+		token, _, err := issuer.Issue(subject)
+		if err != nil {
+			t.Fatalf("failed to issue: %v", err)
+		}
+
+		return token
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "valid", token: issue(svc.issuer, owner), valid: true},
+		{name: "forged", token: issue(forger, owner)},
+		{name: "expired", token: issue(expired, owner)},
+		{name: "not an address", token: issue(svc.issuer, "owner")},
+		{name: "malformed", token: "token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticated, err := svc.Authenticate(ctx, tt.token)
+			if !tt.valid {
+				if !errors.Is(err, entity.ErrUnauthorized) {
+					t.Fatalf("expected unauthorized, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil || authenticated.Hex() != owner {
+				t.Fatalf("expected %s, got %s, %v", owner, authenticated.Hex(), err)
+			}
+		})
+	}
+}
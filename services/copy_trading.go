@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/log"
+	"go.uber.org/zap"
+)
+
+const (
+	_followLockRetryInterval = time.Millisecond * 100
+	_followLockAttempts      = 50
+	_maxFollowRatioBps       = 10 * _bpsDenominator
+	_followOutcomeHistory    = 50
+	_spentDayLayout          = "2006-01-02"
+)
+
+// CopyTradingService mirrors the buys of leaders into their followers'
+// trading accounts.
+type CopyTradingService struct {
+	repo      followsRepo
+	trades    tradesRepo
+	processor tradeProcessor
+	limits    TradeLimits
+	logger    log.Logger
+}
+
+func NewCopyTradingService(
+	repo followsRepo,
+	trades tradesRepo,
+	processor tradeProcessor,
+	limits TradeLimits,
+	logger log.Logger,
+) *CopyTradingService {
+	return &CopyTradingService{
+		repo:      repo,
+		trades:    trades,
+		processor: processor,
+		limits:    limits,
+		logger:    logger,
+	}
+}
+
+// FollowLeader subscribes follower to leader, following again replaces the
+// terms and keeps the outcome history.
+func (c *CopyTradingService) FollowLeader(
+	ctx context.Context,
+	follower common.Address,
+	leader common.Address,
+	terms entity.FollowTerms,
+) (*entity.Follow, error) {
+	if err := c.validateTerms(follower, leader, terms); err != nil {
+		return nil, err
+	}
+
+	var follow *entity.Follow
+	err := c.withFollow(ctx, leader, follower, func() error {
+		existing, err := c.repo.Follow(ctx, leader, follower)
+		switch {
+		case err == nil:
+			follow = existing
+		case errors.Is(err, entity.ErrNoFollowFound):
+			follow = &entity.Follow{
+				Leader:    leader.Hex(),
+				Follower:  follower.Hex(),
+				Outcomes:  []entity.FollowOutcome{},
+				CreatedAt: time.Now(),
+			}
+		default:
+			return err
+		}
+
+		follow.FollowTerms = terms
+		follow.UpdatedAt = time.Now()
+		return c.repo.SaveFollow(ctx, follow)
+	})
+
+	return follow, err
+}
+
+func (c *CopyTradingService) UnfollowLeader(ctx context.Context, follower common.Address, leader common.Address) error {
+	return c.withFollow(ctx, leader, follower, func() error {
+		if _, err := c.repo.Follow(ctx, leader, follower); err != nil {
+			return err
+		}
+
+		return c.repo.DeleteFollow(ctx, leader, follower)
+	})
+}
+
+func (c *CopyTradingService) Following(ctx context.Context, follower common.Address) ([]*entity.Follow, error) {
+	return c.repo.Following(ctx, follower)
+}
+
+func (c *CopyTradingService) Followers(ctx context.Context, leader common.Address) ([]*entity.Follow, error) {
+	return c.repo.Followers(ctx, leader)
+}
+
+// FollowDetails returns the follow with the trade record of every copied
+// trade that still has one.
+func (c *CopyTradingService) FollowDetails(
+	ctx context.Context,
+	follower common.Address,
+	leader common.Address,
+) (*entity.Follow, error) {
+	follow, err := c.repo.Follow(ctx, leader, follower)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range follow.Outcomes {
+		if follow.Outcomes[i].TradeID == "" {
+			continue
+		}
+
+		trade, err := c.trades.Trade(ctx, follow.Outcomes[i].TradeID)
+		switch {
+		case err == nil:
+			follow.Outcomes[i].Trade = trade
+		case errors.Is(err, entity.ErrNoTradesFound):
+		default:
+			return nil, err
+		}
+	}
+
+	return follow, nil
+}
+
+// TradeCompleted fans a leader's completed buy out to its followers, copies
+// and sells are not mirrored.
+func (c *CopyTradingService) TradeCompleted(ctx context.Context, job *entity.TradeRequest, hash common.Hash) {
+	if job.CopyOf != "" ||
+		common.HexToAddress(job.SellToken) != entity.NativeToken ||
+		common.HexToAddress(job.BuyToken) == entity.NativeToken {
+		return
+	}
+
+	leaderEthIn, ok := new(big.Int).SetString(job.AmountIn, 10)
+	if !ok {
+		return
+	}
+
+	leader := common.HexToAddress(job.Owner)
+	follows, err := c.repo.Followers(ctx, leader)
+	if err != nil {
+		c.logger.Error("failed to load followers", zap.String("leader", job.Owner), zap.Error(err))
+		return
+	}
+
+	for _, follow := range follows {
+		follower := common.HexToAddress(follow.Follower)
+		err = c.withFollow(ctx, leader, follower, func() error {
+			return c.copyTrade(ctx, leader, follower, job, hash, leaderEthIn)
+		})
+		if err != nil {
+			c.logger.Error("failed to copy trade", zap.String("follower", follow.Follower), zap.Error(err))
+		}
+	}
+}
+
+func (c *CopyTradingService) copyTrade(
+	ctx context.Context,
+	leader common.Address,
+	follower common.Address,
+	job *entity.TradeRequest,
+	hash common.Hash,
+	leaderEthIn *big.Int,
+) error {
+	follow, err := c.repo.Follow(ctx, leader, follower)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	outcome := entity.FollowOutcome{
+		LeaderTxn: hash.Hex(),
+		Token:     job.BuyToken,
+		At:        now,
+	}
+
+	today := now.UTC().Format(_spentDayLayout)
+	if follow.SpentDay != today {
+		follow.SpentDay = today
+		follow.SpentToday = "0"
+	}
+
+	spent, _ := new(big.Int).SetString(follow.SpentToday, 10)
+	if spent == nil {
+		spent = new(big.Int)
+	}
+
+	amount, reason := copyAmount(&follow.FollowTerms, leaderEthIn, spent)
+	switch {
+	case reason != "":
+		outcome.Status = entity.FollowOutcomeSkipped
+		outcome.Reason = reason
+	default:
+		outcome.EthIn = amount.String()
+		outcome.TradeID = fmt.Sprintf("%s-%s", hash.Hex(), follower.Hex())
+		outcome.Status = entity.FollowOutcomeSubmitted
+
+		controls, err := c.limits.Apply(entity.TradeControls{
+			SlippageBps:       follow.SlippageBps,
+			MaxPriceImpactBps: follow.MaxPriceImpactBps,
+		}, now)
+		if err == nil {
+			err = c.processor.Submit(ctx, &entity.TradeRequest{
+				ID:            outcome.TradeID,
+				Owner:         follower.Hex(),
+				SellToken:     job.SellToken,
+				BuyToken:      job.BuyToken,
+				AmountIn:      amount.String(),
+				CopyOf:        hash.Hex(),
+				TradeControls: controls,
+			})
+		}
+
+		if err != nil {
+			outcome.Status = entity.FollowOutcomeFailed
+			outcome.Reason = err.Error()
+			outcome.TradeID = ""
+		} else {
+			follow.SpentToday = new(big.Int).Add(spent, amount).String()
+		}
+	}
+
+	follow.Outcomes = append(follow.Outcomes, outcome)
+	if len(follow.Outcomes) > _followOutcomeHistory {
+		follow.Outcomes = follow.Outcomes[len(follow.Outcomes)-_followOutcomeHistory:]
+	}
+
+	follow.UpdatedAt = now
+	return c.repo.SaveFollow(ctx, follow)
+}
+
+// copyAmount sizes a copy of leaderEthIn under terms, a non empty reason
+// explains why the copy is skipped.
+func copyAmount(terms *entity.FollowTerms, leaderEthIn *big.Int, spentToday *big.Int) (*big.Int, string) {
+	amount := new(big.Int)
+	if terms.EthIn != "" {
+		amount.SetString(terms.EthIn, 10)
+	} else {
+		amount.Mul(leaderEthIn, big.NewInt(int64(terms.RatioBps)))
+		amount.Div(amount, big.NewInt(_bpsDenominator))
+	}
+
+	if maxPerTrade, ok := new(big.Int).SetString(terms.MaxEthPerTrade, 10); ok && amount.Cmp(maxPerTrade) > 0 {
+		amount = maxPerTrade
+	}
+
+	if amount.Sign() <= 0 {
+		return nil, "copy amount is zero"
+	}
+
+	if maxPerDay, ok := new(big.Int).SetString(terms.MaxEthPerDay, 10); ok {
+		if new(big.Int).Add(spentToday, amount).Cmp(maxPerDay) > 0 {
+			return nil, "daily cap reached"
+		}
+	}
+
+	return amount, ""
+}
+
+func (c *CopyTradingService) validateTerms(follower common.Address, leader common.Address, terms entity.FollowTerms) error {
+	if follower == leader {
+		return entity.ErrInvalidFollowTerms
+	}
+
+	switch {
+	case terms.EthIn != "" && terms.RatioBps != 0:
+		return entity.ErrInvalidFollowTerms
+	case terms.EthIn == "" && (terms.RatioBps <= 0 || terms.RatioBps > _maxFollowRatioBps):
+		return entity.ErrInvalidFollowTerms
+	}
+
+	for _, amount := range []string{terms.EthIn, terms.MaxEthPerTrade, terms.MaxEthPerDay} {
+		if amount == "" {
+			continue
+		}
+
+		parsed, ok := new(big.Int).SetString(amount, 10)
+		if !ok || parsed.Sign() <= 0 {
+			return entity.ErrInvalidFollowTerms
+		}
+	}
+
+	_, err := c.limits.Apply(entity.TradeControls{
+		SlippageBps:       terms.SlippageBps,
+		MaxPriceImpactBps: terms.MaxPriceImpactBps,
+	}, time.Now())
+
+	return err
+}
+
+// withFollow runs update while holding the follow's lock, waiting for copies
+// of concurrent leader trades to finish.
+func (c *CopyTradingService) withFollow(
+	ctx context.Context,
+	leader common.Address,
+	follower common.Address,
+	update func() error,
+) error {
+	token, err := acquireLock(ctx, _followLockRetryInterval, _followLockAttempts, func() (string, error) {
+		return c.repo.Lock(ctx, leader, follower)
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = c.repo.Unlock(context.Background(), leader, follower, token)
+	}()
+
+	return update()
+}
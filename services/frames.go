@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/farcaster"
+)
+
+const (
+	FrameVerificationHub   = "hub"
+	FrameVerificationLocal = "local"
+
+	_frameActionMaxAge = time.Minute * 10
+)
+
+type FrameVerifierConfig struct {
+	Mode string
+	// Signers lists the ed25519 keys accepted in local mode as fid:0xkey.
+	Signers []string
+	// URLPrefix rejects actions signed for frames served elsewhere.
+	URLPrefix string
+}
+
+// FrameVerifier authenticates Farcaster frame actions, either by asking a Hub
+// to validate the signed message or by checking it locally against a known
+// signer set, and resolves the acting FID to the address it trades as.
+type FrameVerifier struct {
+	hub       farcasterHub
+	actions   frameActionsRepo
+	mode      string
+	signers   map[uint64][][]byte
+	urlPrefix string
+}
+
+func NewFrameVerifier(hub farcasterHub, actions frameActionsRepo, cfg FrameVerifierConfig) (*FrameVerifier, error) {
+	if cfg.Mode != FrameVerificationHub && cfg.Mode != FrameVerificationLocal {
+		return nil, fmt.Errorf("unknown frame verification mode %q", cfg.Mode)
+	}
+
+	if cfg.URLPrefix == "" {
+		return nil, errors.New("frame url prefix not configured")
+	}
+
+	signers := make(map[uint64][][]byte)
+	for _, entry := range cfg.Signers {
+		fid, key, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid frame signer %q", entry)
+		}
+
+		parsedFid, err := strconv.ParseUint(fid, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frame signer fid %q, %w", fid, err)
+		}
+
+		parsedKey, err := hexutil.Decode(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frame signer key %q, %w", key, err)
+		}
+
+		signers[parsedFid] = append(signers[parsedFid], parsedKey)
+	}
+
+	return &FrameVerifier{
+		hub:       hub,
+		actions:   actions,
+		mode:      cfg.Mode,
+		signers:   signers,
+		urlPrefix: cfg.URLPrefix,
+	}, nil
+}
+
+func (f *FrameVerifier) VerifyFrameAction(ctx context.Context, payload *entity.FramePayload) (*entity.FrameAction, error) {
+	raw, err := hexutil.Decode(ensureHexPrefix(payload.TrustedData.MessageBytes))
+	if err != nil || len(raw) == 0 {
+		return nil, fmt.Errorf("%w: invalid message bytes", entity.ErrInvalidFrameAction)
+	}
+
+	var action *entity.FrameAction
+	switch f.mode {
+	case FrameVerificationLocal:
+		action, err = f.verifyLocal(raw)
+	default:
+		action, err = f.hub.ValidateMessage(ctx, raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	validFor := time.Until(action.Timestamp.Add(_frameActionMaxAge))
+	if validFor <= 0 {
+		return nil, fmt.Errorf("%w: action expired", entity.ErrInvalidFrameAction)
+	}
+
+	if !strings.HasPrefix(action.URL, f.urlPrefix) {
+		return nil, fmt.Errorf("%w: action signed for %s", entity.ErrInvalidFrameAction, action.URL)
+	}
+
+	owner, err := f.resolveOwner(ctx, action.Fid)
+	if err != nil {
+		return nil, err
+	}
+
+	// each signed message is accepted once for as long as it is valid.
+	if action.Hash == "" {
+		return nil, fmt.Errorf("%w: missing message hash", entity.ErrInvalidFrameAction)
+	}
+
+	claimed, err := f.actions.ClaimFrameAction(ctx, action.Hash, validFor)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, fmt.Errorf("%w: action already used", entity.ErrInvalidFrameAction)
+	}
+
+	action.Owner = owner.Hex()
+	return action, nil
+}
+
+func (f *FrameVerifier) verifyLocal(raw []byte) (*entity.FrameAction, error) {
+	message, err := farcaster.DecodeMessage(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrInvalidFrameAction, err.Error())
+	}
+
+	if err = message.Verify(); err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrInvalidFrameAction, err.Error())
+	}
+
+	registered := slices.ContainsFunc(f.signers[message.Data.Fid], func(key []byte) bool {
+		return slices.Equal(key, message.Signer)
+	})
+	if !registered {
+		return nil, fmt.Errorf("%w: unknown signer for fid %d", entity.ErrInvalidFrameAction, message.Data.Fid)
+	}
+
+	body := message.Data.FrameAction
+	if message.Data.Type != farcaster.MessageTypeFrameAction || body == nil {
+		return nil, fmt.Errorf("%w: not a frame action", entity.ErrInvalidFrameAction)
+	}
+
+	action := &entity.FrameAction{
+		Fid:         message.Data.Fid,
+		URL:         string(body.URL),
+		ButtonIndex: body.ButtonIndex,
+		InputText:   string(body.InputText),
+		State:       string(body.State),
+		Timestamp:   message.Data.Time(),
+		Hash:        hexutil.Encode(message.Hash),
+	}
+
+	if len(body.TransactionID) > 0 {
+		action.TransactionID = hexutil.Encode(body.TransactionID)
+	}
+
+	if len(body.Address) > 0 {
+		action.Address = hexutil.Encode(body.Address)
+	}
+
+	return action, nil
+}
+
+// resolveOwner prefers the first verified Ethereum address of the fid and
+// falls back to its custody address.
+func (f *FrameVerifier) resolveOwner(ctx context.Context, fid uint64) (common.Address, error) {
+	verified, err := f.hub.VerifiedAddresses(ctx, fid)
+	if err != nil {
+		return common.HexToAddress(""), err
+	}
+
+	if len(verified) > 0 {
+		return verified[0], nil
+	}
+
+	custody, err := f.hub.CustodyAddress(ctx, fid)
+	switch {
+	case errors.Is(err, entity.ErrNoFrameOwner):
+		return common.HexToAddress(""), err
+	case err != nil:
+		return common.HexToAddress(""), fmt.Errorf("failed to resolve fid %d, %w", fid, err)
+	}
+
+	return custody, nil
+}
+
+func ensureHexPrefix(value string) string {
+	if strings.HasPrefix(value, "0x") {
+		return value
+	}
+
+	return "0x" + value
+}
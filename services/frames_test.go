@@ -0,0 +1,220 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/farcaster"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+	"github.com/rahul0tripathi/framecoiner/repo"
+	"github.com/zeebo/blake3"
+)
+
+const (
+	_testFid       = 42
+	_testURLPrefix = "https://frames.example.com/"
+)
+
+var _testFrameOwner = common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+// stubHub validates every message as action and resolves every fid to
+// _testFrameOwner.
+type stubHub struct {
+	action *entity.FrameAction
+	err    error
+}
+
+func (h *stubHub) ValidateMessage(ctx context.Context, raw []byte) (*entity.FrameAction, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	action := *h.action
+	return &action, nil
+}
+
+func (h *stubHub) VerifiedAddresses(ctx context.Context, fid uint64) ([]common.Address, error) {
+	return []common.Address{_testFrameOwner}, nil
+}
+
+func (h *stubHub) CustodyAddress(ctx context.Context, fid uint64) (common.Address, error) {
+	return common.HexToAddress(""), entity.ErrNoFrameOwner
+}
+
+func appendProtoVarint(buf []byte, field uint64, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, field<<3)
+	return binary.AppendUvarint(buf, value)
+}
+
+func appendProtoBytes(buf []byte, field uint64, value []byte) []byte {
+	buf = binary.AppendUvarint(buf, field<<3|2)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+// signFrameAction encodes a frame action message for url signed by key.
+func signFrameAction(key ed25519.PrivateKey, url string, at time.Time) *entity.FramePayload {
+	body := appendProtoBytes(nil, 1, []byte(url))
+	body = appendProtoVarint(body, 2, 1)
+
+	data := appendProtoVarint(nil, 1, farcaster.MessageTypeFrameAction)
+	data = appendProtoVarint(data, 2, _testFid)
+	data = appendProtoVarint(data, 3, uint64(at.Sub(farcaster.Epoch)/time.Second))
+	data = appendProtoBytes(data, 16, body)
+
+	sum := blake3.Sum256(data)
+	message := appendProtoBytes(nil, 1, data)
+	message = appendProtoBytes(message, 2, sum[:20])
+	message = appendProtoVarint(message, 3, 1)
+	message = appendProtoBytes(message, 4, ed25519.Sign(key, sum[:20]))
+	message = appendProtoVarint(message, 5, 1)
+	message = appendProtoBytes(message, 6, key.Public().(ed25519.PublicKey))
+
+	payload := &entity.FramePayload{}
+	payload.TrustedData.MessageBytes = hexutil.Encode(message)[2:]
+	return payload
+}
+
+func newTestFrameVerifier(t *testing.T, hub farcasterHub, mode string, signers ...string) *FrameVerifier {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: server.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	verifier, err := NewFrameVerifier(hub, repo.NewFrameActionsRepo(storage), FrameVerifierConfig{
+		Mode:      mode,
+		Signers:   signers,
+		URLPrefix: _testURLPrefix,
+	})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	return verifier
+}
+
+func newTestSigner(t *testing.T) (ed25519.PrivateKey, string) {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key, "42:" + hexutil.Encode(key.Public().(ed25519.PublicKey))
+}
+
+func TestVerifyFrameActionLocal(t *testing.T) {
+	key, signer := newTestSigner(t)
+	other, _ := newTestSigner(t)
+
+	tampered := signFrameAction(key, _testURLPrefix+"buy", time.Now())
+	raw, _ := hexutil.Decode("0x" + tampered.TrustedData.MessageBytes)
+	raw[bytes.Index(raw, []byte("buy"))] = 'g'
+	tampered.TrustedData.MessageBytes = hexutil.Encode(raw)
+
+	tests := []struct {
+		name    string
+		payload *entity.FramePayload
+		valid   bool
+	}{
+		{name: "valid", payload: signFrameAction(key, _testURLPrefix+"buy", time.Now()), valid: true},
+		{name: "tampered body", payload: tampered},
+		{name: "unknown signer", payload: signFrameAction(other, _testURLPrefix+"buy", time.Now())},
+		{name: "wrong url", payload: signFrameAction(key, "https://evil.example.com/buy", time.Now())},
+		{name: "expired", payload: signFrameAction(key, _testURLPrefix+"buy", time.Now().Add(-time.Hour))},
+		{name: "not hex", payload: &entity.FramePayload{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := newTestFrameVerifier(t, &stubHub{}, FrameVerificationLocal, signer)
+
+			action, err := verifier.VerifyFrameAction(context.Background(), tt.payload)
+			if !tt.valid {
+				if !errors.Is(err, entity.ErrInvalidFrameAction) {
+					t.Fatalf("expected an invalid frame action, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to verify: %v", err)
+			}
+
+			if action.Fid != _testFid || action.URL != _testURLPrefix+"buy" || action.Owner != _testFrameOwner.Hex() {
+				t.Fatalf("unexpected action %+v", action)
+			}
+		})
+	}
+}
+
+func TestVerifyFrameActionRejectsReplay(t *testing.T) {
+	key, signer := newTestSigner(t)
+	verifier := newTestFrameVerifier(t, &stubHub{}, FrameVerificationLocal, signer)
+	payload := signFrameAction(key, _testURLPrefix+"buy", time.Now())
+
+	if _, err := verifier.VerifyFrameAction(context.Background(), payload); err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	if _, err := verifier.VerifyFrameAction(context.Background(), payload); !errors.Is(err, entity.ErrInvalidFrameAction) {
+		t.Fatalf("expected a replayed action to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyFrameActionHub(t *testing.T) {
+	payload := &entity.FramePayload{}
+	payload.TrustedData.MessageBytes = "0a01"
+
+	action := &entity.FrameAction{
+		Fid:       _testFid,
+		URL:       _testURLPrefix + "buy",
+		Timestamp: time.Now(),
+		Hash:      "0xabcd",
+	}
+
+	tests := []struct {
+		name  string
+		hub   *stubHub
+		valid bool
+	}{
+		{name: "valid", hub: &stubHub{action: action}, valid: true},
+		{name: "rejected by hub", hub: &stubHub{err: entity.ErrInvalidFrameAction}},
+		{name: "wrong url", hub: &stubHub{action: &entity.FrameAction{URL: "https://evil.example.com/", Timestamp: time.Now(), Hash: "0xabcd"}}},
+		{name: "missing hash", hub: &stubHub{action: &entity.FrameAction{URL: _testURLPrefix, Timestamp: time.Now()}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := newTestFrameVerifier(t, tt.hub, FrameVerificationHub)
+
+			verified, err := verifier.VerifyFrameAction(context.Background(), payload)
+			if !tt.valid {
+				if !errors.Is(err, entity.ErrInvalidFrameAction) {
+					t.Fatalf("expected an invalid frame action, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil || verified.Owner != _testFrameOwner.Hex() {
+				t.Fatalf("unexpected action %+v, %v", verified, err)
+			}
+		})
+	}
+}
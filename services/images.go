@@ -0,0 +1,196 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-resty/resty/v2"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/render"
+)
+
+const (
+	_renderCacheExpiry = time.Hour
+	_logoFetchTimeout  = time.Second * 3
+	_maxLogoSize       = 1 << 20
+	_maxLogoDimension  = 1024
+)
+
+type ImageService struct {
+	metadata tokenMetadataProvider
+	accounts tradingAccountProvider
+	renders  rendersRepo
+	client   *resty.Client
+}
+
+func NewImageService(
+	metadata tokenMetadataProvider,
+	accounts tradingAccountProvider,
+	renders rendersRepo,
+) *ImageService {
+	return &ImageService{
+		metadata: metadata,
+		accounts: accounts,
+		renders:  renders,
+		client:   resty.New().SetTimeout(_logoFetchTimeout),
+	}
+}
+
+// RenderTokenImage renders the token card for request, reusing a cached PNG
+// when nothing drawn on the card has changed.
+func (i *ImageService) RenderTokenImage(ctx context.Context, request *entity.TokenImageRequest) (*entity.RenderedImage, error) {
+	card, err := i.tokenCard(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(card)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	cached, err := i.renders.Render(ctx, hash)
+	switch {
+	case err == nil:
+		return &entity.RenderedImage{PNG: cached, ETag: hash}, nil
+	case !errors.Is(err, entity.ErrEmpty):
+		return nil, err
+	}
+
+	rendered, err := i.draw(ctx, card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render token image, %w", err)
+	}
+
+	if err = i.renders.SaveRender(ctx, hash, rendered, _renderCacheExpiry); err != nil {
+		return nil, err
+	}
+
+	return &entity.RenderedImage{PNG: rendered, ETag: hash}, nil
+}
+
+func (i *ImageService) tokenCard(ctx context.Context, request *entity.TokenImageRequest) (*entity.TokenCard, error) {
+	metadata, err := i.metadata.GetTokenMetadata(ctx, request.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	card := &entity.TokenCard{
+		Token:  request.Token.Hex(),
+		Ticker: metadata.Ticker,
+		Price:  metadata.Price,
+		Logo:   metadata.Logo,
+		Wide:   request.Wide,
+	}
+
+	if request.Owner == nil {
+		return card, nil
+	}
+
+	// owners without a trading account get the plain card, the image route
+	// is public and never provisions accounts.
+	account, err := i.accounts.FindTradingAccount(ctx, *request.Owner)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrNoAccountFound):
+		return card, nil
+	default:
+		return nil, err
+	}
+
+	card.Balance = account.Balance
+
+	trade, err := i.accounts.LatestTrade(ctx, *request.Owner)
+	switch {
+	case err == nil:
+		if common.IsHexAddress(trade.Request.BuyToken) && common.HexToAddress(trade.Request.BuyToken) == request.Token {
+			card.TradeStatus = trade.Status()
+		}
+	case !errors.Is(err, entity.ErrNoTradesFound):
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (i *ImageService) draw(ctx context.Context, card *entity.TokenCard) ([]byte, error) {
+	lines := []render.Line{
+		{Text: fmt.Sprintf("$%s", card.Price), Size: 44},
+		{Text: card.Token, Size: 18, Muted: true},
+	}
+
+	if card.Balance != "" {
+		lines = append(lines, render.Line{Text: fmt.Sprintf("Trading balance %s", card.Balance), Size: 28})
+	}
+
+	switch card.TradeStatus {
+	case entity.TradeStatusPending, entity.TradeStatusSubmitted:
+		lines = append(lines, render.Line{Text: "Trade pending…", Size: 28, Bold: true})
+	case entity.TradeStatusSuccess:
+		lines = append(lines, render.Line{Text: "Trade complete", Size: 28, Bold: true})
+	case entity.TradeStatusCancelled:
+		lines = append(lines, render.Line{Text: "Trade cancelled", Size: 28, Bold: true})
+	case entity.TradeStatusFailed:
+		lines = append(lines, render.Line{Text: "Trade failed", Size: 28, Bold: true})
+	}
+
+	return (&render.Card{
+		Wide:  card.Wide,
+		Logo:  i.fetchLogo(ctx, card.Logo),
+		Title: card.Ticker,
+		Lines: lines,
+	}).PNG()
+}
+
+// fetchLogo returns nil when the logo cannot be loaded, the card is then drawn
+// with a placeholder instead.
+func (i *ImageService) fetchLogo(ctx context.Context, url string) image.Image {
+	if url == "" {
+		return nil
+	}
+
+	resp, err := i.client.R().SetContext(ctx).SetDoNotParseResponse(true).Get(url)
+	if err != nil {
+		return nil
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.IsError() {
+		return nil
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(body, _maxLogoSize+1))
+	if err != nil || len(raw) > _maxLogoSize {
+		return nil
+	}
+
+	// the header is checked first so a small file declaring a huge image is
+	// never decoded.
+	config, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || config.Width > _maxLogoDimension || config.Height > _maxLogoDimension {
+		return nil
+	}
+
+	logo, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil
+	}
+
+	return logo
+}
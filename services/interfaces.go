@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -10,6 +11,7 @@ import (
 )
 
 type keyManager interface {
+	LookupSigningAddress(ctx context.Context, owner common.Address) (common.Address, error)
 	SigningAddress(ctx context.Context, owner common.Address) (common.Address, error)
 	SignTx(
 		ctx context.Context,
@@ -20,14 +22,149 @@ type keyManager interface {
 }
 
 type quoter interface {
-	GetQuote(ctx context.Context, token common.Address, ethIn string) (*entity.Quote, error)
+	GetQuote(ctx context.Context, request *entity.QuoteRequest) (*entity.Quote, error)
+}
+
+type l1FeeOracle interface {
+	L1Fee(ctx context.Context, transaction *types.Transaction) (*big.Int, error)
+}
+
+type quotesRepo interface {
+	SaveQuoteTerms(ctx context.Context, terms *entity.QuoteTerms, expiry time.Duration) error
+	QuoteTerms(ctx context.Context, id string) (*entity.QuoteTerms, error)
+	ConsumeQuoteTerms(ctx context.Context, id string) error
 }
 
 type tradesRepo interface {
 	LatestTrade(ctx context.Context, owner common.Address) (*entity.Trade, error)
+	Trade(ctx context.Context, jobID string) (*entity.Trade, error)
 	UpdateTrade(ctx context.Context, owner common.Address, trade *entity.Trade) error
+	SaveTrade(ctx context.Context, trade *entity.Trade) error
 }
 
 type tradeProcessor interface {
 	Submit(ctx context.Context, job *entity.TradeRequest) error
+	Track(ctx context.Context, job *entity.TrackRequest) error
+	Replace(ctx context.Context, owner common.Address, id string, kind string) (*entity.Trade, error)
+	Withdraw(ctx context.Context, job *entity.WithdrawRequest) error
+}
+
+type pendingTradesRepo interface {
+	SavePendingTrade(ctx context.Context, pending *entity.PendingTrade, expiry time.Duration) error
+	PendingTrade(ctx context.Context, owner common.Address, id string) (*entity.PendingTrade, error)
+	PendingTrades(ctx context.Context) ([]*entity.PendingTrade, error)
+	DeletePendingTrade(ctx context.Context, owner common.Address, id string) error
+	Lock(ctx context.Context, owner common.Address, id string) (string, error)
+	Unlock(ctx context.Context, owner common.Address, id string, token string) error
+}
+
+type noncesRepo interface {
+	Lock(ctx context.Context, signer common.Address) (string, error)
+	Unlock(ctx context.Context, signer common.Address, token string) error
+	NonceState(ctx context.Context, signer common.Address) (*entity.NonceState, error)
+	UpdateNonceState(ctx context.Context, signer common.Address, state *entity.NonceState) error
+}
+
+type authRepo interface {
+	SaveNonce(ctx context.Context, owner common.Address, nonce string, expiry time.Duration) error
+	ConsumeNonce(ctx context.Context, owner common.Address, nonce string) error
+}
+
+type frameActionsRepo interface {
+	ClaimFrameAction(ctx context.Context, hash string, expiry time.Duration) (bool, error)
+}
+
+type farcasterHub interface {
+	ValidateMessage(ctx context.Context, raw []byte) (*entity.FrameAction, error)
+	VerifiedAddresses(ctx context.Context, fid uint64) ([]common.Address, error)
+	CustodyAddress(ctx context.Context, fid uint64) (common.Address, error)
+}
+
+type tokenMetadataProvider interface {
+	GetTokenMetadata(ctx context.Context, token common.Address) (*entity.TokenMetadata, error)
+}
+
+type tokenPricer interface {
+	TokenPrice(ctx context.Context, token common.Address) (float64, error)
+}
+
+type ordersRepo interface {
+	SaveOrder(ctx context.Context, order *entity.LimitOrder, expiry time.Duration) error
+	Order(ctx context.Context, owner common.Address, id string) (*entity.LimitOrder, error)
+	Orders(ctx context.Context, owner common.Address) ([]*entity.LimitOrder, error)
+	OrderTokens(ctx context.Context) ([]common.Address, error)
+	OpenOrders(ctx context.Context, token common.Address) ([]*entity.LimitOrder, error)
+	IndexOrders(ctx context.Context) error
+	ClaimOrder(ctx context.Context, id string) (bool, error)
+	ReleaseOrder(ctx context.Context, id string) error
+}
+
+type positionsRepo interface {
+	SavePosition(ctx context.Context, position *entity.Position, expiry time.Duration) error
+	Position(ctx context.Context, owner common.Address, id string) (*entity.Position, error)
+	Positions(ctx context.Context, owner common.Address) ([]*entity.Position, error)
+	AllPositions(ctx context.Context) ([]*entity.Position, error)
+	ClaimPosition(ctx context.Context, id string) (bool, error)
+	ReleasePosition(ctx context.Context, id string) error
+}
+
+type schedulesRepo interface {
+	SaveSchedule(ctx context.Context, schedule *entity.Schedule, expiry time.Duration) error
+	Schedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error)
+	Schedules(ctx context.Context, owner common.Address) ([]*entity.Schedule, error)
+	AllSchedules(ctx context.Context) ([]*entity.Schedule, error)
+	Lock(ctx context.Context, id string) (string, error)
+	Unlock(ctx context.Context, id string, token string) error
+}
+
+type followsRepo interface {
+	SaveFollow(ctx context.Context, follow *entity.Follow) error
+	Follow(ctx context.Context, leader common.Address, follower common.Address) (*entity.Follow, error)
+	DeleteFollow(ctx context.Context, leader common.Address, follower common.Address) error
+	Followers(ctx context.Context, leader common.Address) ([]*entity.Follow, error)
+	Following(ctx context.Context, follower common.Address) ([]*entity.Follow, error)
+	Lock(ctx context.Context, leader common.Address, follower common.Address) (string, error)
+	Unlock(ctx context.Context, leader common.Address, follower common.Address, token string) error
+}
+
+type sweepsRepo interface {
+	SaveSweep(ctx context.Context, sweep *entity.Sweep, expiry time.Duration) error
+	Sweep(ctx context.Context, owner common.Address, id string) (*entity.Sweep, error)
+	Lock(ctx context.Context, owner common.Address) (string, error)
+	Unlock(ctx context.Context, owner common.Address, token string) error
+	Tokens(ctx context.Context, owner common.Address) ([]common.Address, error)
+	Owners(ctx context.Context) ([]common.Address, error)
+}
+
+type preferencesRepo interface {
+	SavePreferences(ctx context.Context, preferences *entity.Preferences) error
+	Preferences(ctx context.Context, owner common.Address) (*entity.Preferences, error)
+}
+
+type tokenTracker interface {
+	TrackToken(ctx context.Context, owner common.Address, token common.Address) error
+}
+
+type tokenSweeper interface {
+	SweepToken(
+		ctx context.Context,
+		owner common.Address,
+		recipient common.Address,
+		token common.Address,
+	) (*big.Int, *common.Hash, error)
+}
+
+// tradeListener is told about every trade the processor completed.
+type tradeListener interface {
+	TradeCompleted(ctx context.Context, job *entity.TradeRequest, hash common.Hash)
+}
+
+type tradingAccountProvider interface {
+	FindTradingAccount(ctx context.Context, address common.Address) (*entity.TradingAccount, error)
+	LatestTrade(ctx context.Context, address common.Address) (*entity.Trade, error)
+}
+
+type rendersRepo interface {
+	Render(ctx context.Context, hash string) ([]byte, error)
+	SaveRender(ctx context.Context, hash string, png []byte, expiry time.Duration) error
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"time"
+
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_defaultTradeDeadline = time.Minute * 2
+)
+
+// TradeLimits are the global bounds per trade controls must stay within.
+type TradeLimits struct {
+	MaxSlippageBps    int
+	MaxPriceImpactBps int
+	MaxDeadline       time.Duration
+}
+
+// Apply fills unset controls with the defaults and rejects controls outside
+// the limits.
+func (l TradeLimits) Apply(controls entity.TradeControls, now time.Time) (entity.TradeControls, error) {
+	if controls.SlippageBps == 0 {
+		controls.SlippageBps = min(entity.DefaultSlippageBps, l.MaxSlippageBps)
+	}
+
+	if controls.MaxPriceImpactBps == 0 {
+		controls.MaxPriceImpactBps = min(entity.DefaultMaxPriceImpactBps, l.MaxPriceImpactBps)
+	}
+
+	if controls.Deadline == 0 {
+		controls.Deadline = now.Add(min(_defaultTradeDeadline, l.MaxDeadline)).Unix()
+	}
+
+	switch {
+	case controls.SlippageBps < 0 || controls.SlippageBps > l.MaxSlippageBps:
+		return controls, entity.ErrInvalidTradeControls
+	case controls.MaxPriceImpactBps < 0 || controls.MaxPriceImpactBps > l.MaxPriceImpactBps:
+		return controls, entity.ErrInvalidTradeControls
+	case controls.Deadline <= now.Unix() || controls.Deadline > now.Add(l.MaxDeadline).Unix():
+		return controls, entity.ErrInvalidTradeControls
+	}
+
+	return controls, nil
+}
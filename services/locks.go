@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+// acquireLock calls lock until it takes the lock, retrying every interval
+// while another holder has it. It gives up after attempts retries, or only
+// once ctx is done when attempts is 0, and returns the lock's token.
+func acquireLock(
+	ctx context.Context,
+	interval time.Duration,
+	attempts int,
+	lock func() (string, error),
+) (string, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := lock()
+		if !errors.Is(err, entity.ErrLockNotAcquired) || (attempts > 0 && attempt == attempts) {
+			return token, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -38,16 +38,11 @@ func NewTokenMetadataService(backend *ethclient.Client, cfg integrations.ZeroXCo
 }
 
 func (m *MetadataService) GetTokenMetadata(ctx context.Context, token common.Address) (*entity.TokenMetadata, error) {
-	response := &priceResponse{}
-	resp, err := m.client.R().SetContext(ctx).Get(fmt.Sprintf("prices/current/base:%s?searchWidth=4h", token.Hex()))
+	price, err := m.TokenPrice(ctx, token)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = json.Unmarshal(resp.Body(), response); err != nil {
-		return nil, err
-	}
-
 	binding, err := entity.NewErc20Binding(token, m.backend)
 	if err != nil {
 		return nil, err
@@ -60,14 +55,29 @@ func (m *MetadataService) GetTokenMetadata(ctx context.Context, token common.Add
 		return nil, err
 	}
 
-	metadata, ok := response.Coins[fmt.Sprintf("base:%s", token.String())]
-	if !ok {
-		return nil, errors.New("no price found")
-	}
-
 	return &entity.TokenMetadata{
 		Ticker: symbol,
-		Price:  fmt.Sprintf("%.5f", metadata.Price),
+		Price:  fmt.Sprintf("%.5f", price),
 		Logo:   fmt.Sprintf("https://token-registry.s3.amazonaws.com/icons/tokens/base/128/%s.png", token.String()),
 	}, nil
 }
+
+// TokenPrice returns the unrounded USD price of token.
+func (m *MetadataService) TokenPrice(ctx context.Context, token common.Address) (float64, error) {
+	response := &priceResponse{}
+	resp, err := m.client.R().SetContext(ctx).Get(fmt.Sprintf("prices/current/base:%s?searchWidth=4h", token.Hex()))
+	if err != nil {
+		return 0, err
+	}
+
+	if err = json.Unmarshal(resp.Body(), response); err != nil {
+		return 0, err
+	}
+
+	metadata, ok := response.Coins[fmt.Sprintf("base:%s", token.String())]
+	if !ok {
+		return 0, errors.New("no price found")
+	}
+
+	return metadata.Price, nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_nonceLockRetryInterval = time.Millisecond * 50
+	_nonceGapTimeout        = time.Minute * 2
+)
+
+// NonceManager hands out nonces per signing address. Reservations are kept in
+// the nonces repo under a distributed lock so instances sharing the same
+// storage never reserve the same nonce, and are resynced with the chain when
+// a gap is left behind or the node reports the nonce as already used.
+type NonceManager struct {
+	backend *ethclient.Client
+	repo    noncesRepo
+	mu      sync.Mutex
+	locks   map[common.Address]*sync.Mutex
+}
+
+func NewNonceManager(repo noncesRepo, backend *ethclient.Client) *NonceManager {
+	return &NonceManager{
+		backend: backend,
+		repo:    repo,
+		locks:   make(map[common.Address]*sync.Mutex),
+	}
+}
+
+func (n *NonceManager) Reserve(ctx context.Context, signer common.Address) (uint64, error) {
+	var nonce uint64
+	err := n.withState(ctx, signer, func(state *entity.NonceState) error {
+		pending, err := n.backend.PendingNonceAt(ctx, signer)
+		if err != nil {
+			return err
+		}
+
+		n.sync(state, pending)
+		if len(state.Released) > 0 {
+			nonce, state.Released = state.Released[0], state.Released[1:]
+			return nil
+		}
+
+		nonce = state.Next
+		state.Next++
+		return nil
+	})
+
+	return nonce, err
+}
+
+// Release returns a nonce that was reserved but never broadcast.
+func (n *NonceManager) Release(ctx context.Context, signer common.Address, nonce uint64) error {
+	return n.withState(ctx, signer, func(state *entity.NonceState) error {
+		switch {
+		case nonce >= state.Next:
+		case nonce == state.Next-1:
+			state.Next--
+		case !slices.Contains(state.Released, nonce):
+			state.Released = append(state.Released, nonce)
+			slices.Sort(state.Released)
+		}
+
+		return nil
+	})
+}
+
+// Resync drops every local reservation and restarts from the chain's pending
+// nonce.
+func (n *NonceManager) Resync(ctx context.Context, signer common.Address) error {
+	return n.withState(ctx, signer, func(state *entity.NonceState) error {
+		pending, err := n.backend.PendingNonceAt(ctx, signer)
+		if err != nil {
+			return err
+		}
+
+		state.Next = pending
+		state.Released = nil
+		return nil
+	})
+}
+
+// sync moves the reservation window forward when the chain is ahead of it,
+// and back to the chain when reserved nonces were never mined within
+// _nonceGapTimeout, which otherwise would block every later transaction.
+func (n *NonceManager) sync(state *entity.NonceState, pending uint64) {
+	if state.Next > pending && time.Since(state.UpdatedAt) > _nonceGapTimeout {
+		state.Next = pending
+		state.Released = nil
+	}
+
+	if state.Next < pending {
+		state.Next = pending
+	}
+
+	released := state.Released[:0]
+	for _, nonce := range state.Released {
+		if nonce >= pending && nonce < state.Next {
+			released = append(released, nonce)
+		}
+	}
+
+	state.Released = released
+}
+
+func (n *NonceManager) withState(
+	ctx context.Context,
+	signer common.Address,
+	update func(state *entity.NonceState) error,
+) error {
+	local := n.signerLock(signer)
+	local.Lock()
+	defer local.Unlock()
+
+	token, err := acquireLock(ctx, _nonceLockRetryInterval, 0, func() (string, error) {
+		return n.repo.Lock(ctx, signer)
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = n.repo.Unlock(context.Background(), signer, token)
+	}()
+
+	state, err := n.repo.NonceState(ctx, signer)
+	if err != nil {
+		return err
+	}
+
+	if err = update(state); err != nil {
+		return err
+	}
+
+	state.UpdatedAt = time.Now()
+	return n.repo.UpdateNonceState(ctx, signer, state)
+}
+
+func (n *NonceManager) signerLock(signer common.Address) *sync.Mutex {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	lock, ok := n.locks[signer]
+	if !ok {
+		lock = &sync.Mutex{}
+		n.locks[signer] = lock
+	}
+
+	return lock
+}
+
+func isNonceTooLow(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_orderIDLength     = 16
+	_closedOrderExpiry = time.Hour * 24 * 7
+)
+
+type OrderService struct {
+	repo   ordersRepo
+	trades tradesRepo
+}
+
+func NewOrderService(repo ordersRepo, trades tradesRepo) *OrderService {
+	return &OrderService{repo: repo, trades: trades}
+}
+
+func (o *OrderService) PlaceLimitOrder(
+	ctx context.Context,
+	owner common.Address,
+	token common.Address,
+	ethIn string,
+	limitPrice string,
+) (*entity.LimitOrder, error) {
+	amount, ok := new(big.Int).SetString(ethIn, 10)
+	if !ok || amount.Sign() <= 0 {
+		return nil, entity.ErrInvalidSellAmount
+	}
+
+	price, err := strconv.ParseFloat(limitPrice, 64)
+	if err != nil || price <= 0 {
+		return nil, entity.ErrInvalidLimitPrice
+	}
+
+	id := make([]byte, _orderIDLength)
+	if _, err = rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order := &entity.LimitOrder{
+		ID:         hexutil.Encode(id),
+		Owner:      owner.Hex(),
+		Token:      token.Hex(),
+		EthIn:      amount.String(),
+		LimitPrice: limitPrice,
+		Status:     entity.OrderStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	// open orders never expire, they stay in the book until triggered or
+	// cancelled.
+	if err = o.repo.SaveOrder(ctx, order, 0); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (o *OrderService) Orders(ctx context.Context, owner common.Address) ([]*entity.LimitOrder, error) {
+	orders, err := o.repo.Orders(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, order := range orders {
+		if err = o.resolveTrade(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+// Order returns the order with the trade record it submitted, if it still
+// has one.
+func (o *OrderService) Order(ctx context.Context, owner common.Address, id string) (*entity.LimitOrder, error) {
+	order, err := o.repo.Order(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = o.resolveTrade(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// CancelOrder claims the order the same way the price watcher does before
+// triggering it, whichever claims first wins.
+func (o *OrderService) CancelOrder(ctx context.Context, owner common.Address, id string) (*entity.LimitOrder, error) {
+	order, err := o.repo.Order(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != entity.OrderStatusOpen {
+		return nil, entity.ErrOrderNotOpen
+	}
+
+	claimed, err := o.repo.ClaimOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, entity.ErrOrderNotOpen
+	}
+
+	order.Status = entity.OrderStatusCancelled
+	order.UpdatedAt = time.Now()
+	if err = o.repo.SaveOrder(ctx, order, _closedOrderExpiry); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// resolveTrade attaches the trade a triggered order submitted, an order whose
+// trade failed, for one because the price moved past the limit before
+// execution, is reported as failed rather than triggered.
+func (o *OrderService) resolveTrade(ctx context.Context, order *entity.LimitOrder) error {
+	if order.TradeID == "" {
+		return nil
+	}
+
+	trade, err := o.trades.Trade(ctx, order.TradeID)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrNoTradesFound):
+		return nil
+	default:
+		return err
+	}
+
+	order.Trade = trade
+	switch trade.Status() {
+	case entity.TradeStatusFailed, entity.TradeStatusCancelled:
+		order.Status = entity.OrderStatusFailed
+		order.Error = trade.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/log"
+	"go.uber.org/zap"
+)
+
+// PositionMonitor values held positions at the current quote and sells them
+// once their stop-loss or take-profit threshold is crossed.
+type PositionMonitor struct {
+	backend    *ethclient.Client
+	manager    keyManager
+	positions  positionsRepo
+	swapQuoter quoter
+	processor  tradeProcessor
+	limits     TradeLimits
+	interval   time.Duration
+	logger     log.Logger
+}
+
+func NewPositionMonitor(
+	backend *ethclient.Client,
+	manager keyManager,
+	positions positionsRepo,
+	swapQuoter quoter,
+	processor tradeProcessor,
+	limits TradeLimits,
+	interval time.Duration,
+	logger log.Logger,
+) *PositionMonitor {
+	return &PositionMonitor{
+		backend:    backend,
+		manager:    manager,
+		positions:  positions,
+		swapQuoter: swapQuoter,
+		processor:  processor,
+		limits:     limits,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+func (p *PositionMonitor) Run(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.poll(ctx); err != nil {
+					p.logger.Error("failed to poll positions", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+func (p *PositionMonitor) poll(ctx context.Context) error {
+	positions, err := p.positions.AllPositions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, position := range positions {
+		if position.Status != entity.PositionStatusOpen || !position.Held {
+			continue
+		}
+
+		if position.StopLossBps == 0 && position.TakeProfitBps == 0 {
+			continue
+		}
+
+		if err = p.evaluate(ctx, position); err != nil {
+			p.logger.Error("failed to evaluate position", zap.String("position", position.ID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (p *PositionMonitor) evaluate(ctx context.Context, position *entity.Position) error {
+	change, err := p.changeBps(ctx, position)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case position.StopLossBps != 0 && change <= -int64(position.StopLossBps):
+		return p.trigger(ctx, position, entity.PositionTriggerStopLoss)
+	case position.TakeProfitBps != 0 && change >= int64(position.TakeProfitBps):
+		return p.trigger(ctx, position, entity.PositionTriggerTakeProfit)
+	default:
+		return nil
+	}
+}
+
+// changeBps is the position's gain or loss against the ETH it cost, valued
+// at what selling its whole amount would return now.
+func (p *PositionMonitor) changeBps(ctx context.Context, position *entity.Position) (int64, error) {
+	quote, err := p.swapQuoter.GetQuote(ctx, &entity.QuoteRequest{
+		SellToken:  common.HexToAddress(position.Token),
+		BuyToken:   entity.NativeToken,
+		SellAmount: position.Amount,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := new(big.Int).SetString(quote.BuyAmount, 10)
+	if !ok {
+		return 0, errors.New("quote has no buy amount")
+	}
+
+	cost, ok := new(big.Int).SetString(position.EthIn, 10)
+	if !ok || cost.Sign() <= 0 {
+		return 0, errors.New("position has no cost")
+	}
+
+	change := new(big.Int).Mul(new(big.Int).Sub(value, cost), big.NewInt(_bpsDenominator))
+	return change.Quo(change, cost).Int64(), nil
+}
+
+// trigger claims the position and submits its sell under the position's ID.
+// The claim is released when the sell cannot be submitted, so the position
+// stays open to retry, unless nothing of it is held anymore.
+func (p *PositionMonitor) trigger(ctx context.Context, position *entity.Position, reason string) error {
+	claimed, err := p.positions.ClaimPosition(ctx, position.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	position.TriggeredBy = reason
+	position.UpdatedAt = time.Now()
+
+	amount, err := p.sellAmount(ctx, position)
+	if errors.Is(err, entity.ErrNothingToSell) {
+		position.Status = entity.PositionStatusFailed
+		position.Error = err.Error()
+		return p.positions.SavePosition(ctx, position, _closedPositionExpiry)
+	}
+
+	if err == nil {
+		var controls entity.TradeControls
+		if controls, err = p.limits.Apply(entity.TradeControls{}, time.Now()); err == nil {
+			err = p.processor.Submit(ctx, &entity.TradeRequest{
+				ID:            position.ID,
+				Owner:         position.Owner,
+				SellToken:     position.Token,
+				BuyToken:      entity.NativeToken.Hex(),
+				AmountIn:      amount.String(),
+				TradeControls: controls,
+			})
+		}
+	}
+
+	if err != nil {
+		if releaseErr := p.positions.ReleasePosition(ctx, position.ID); releaseErr != nil {
+			p.logger.Error("failed to release position", zap.String("position", position.ID), zap.Error(releaseErr))
+		}
+
+		return fmt.Errorf("failed to submit position sell, %w", err)
+	}
+
+	position.Status = entity.PositionStatusTriggered
+	position.TradeID = position.ID
+	return p.positions.SavePosition(ctx, position, _closedPositionExpiry)
+}
+
+// sellAmount caps the position's amount at what the trading account still
+// holds of its token, part of it may have been withdrawn or swept since.
+func (p *PositionMonitor) sellAmount(ctx context.Context, position *entity.Position) (*big.Int, error) {
+	signer, err := p.manager.LookupSigningAddress(ctx, common.HexToAddress(position.Owner))
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := entity.NewErc20Binding(common.HexToAddress(position.Token), p.backend)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, ok := new(big.Int).SetString(position.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse position amount %q", position.Amount)
+	}
+
+	if balance.Cmp(amount) < 0 {
+		amount = balance
+	}
+
+	if amount.Sign() <= 0 {
+		return nil, entity.ErrNothingToSell
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_closedPositionExpiry = time.Hour * 24 * 7
+)
+
+type PositionService struct {
+	repo   positionsRepo
+	trades tradesRepo
+}
+
+func NewPositionService(repo positionsRepo, trades tradesRepo) *PositionService {
+	return &PositionService{repo: repo, trades: trades}
+}
+
+func (p *PositionService) Positions(ctx context.Context, owner common.Address) ([]*entity.Position, error) {
+	positions, err := p.repo.Positions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, position := range positions {
+		if err = p.resolveTrade(ctx, position); err != nil {
+			return nil, err
+		}
+	}
+
+	return positions, nil
+}
+
+// Position returns the position with the trade record of the sell it
+// triggered, if it still has one.
+func (p *PositionService) Position(ctx context.Context, owner common.Address, id string) (*entity.Position, error) {
+	position, err := p.repo.Position(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.resolveTrade(ctx, position); err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+// SetThresholds attaches stop-loss and take-profit thresholds to a held open
+// position, zero clears either.
+func (p *PositionService) SetThresholds(
+	ctx context.Context,
+	owner common.Address,
+	id string,
+	stopLossBps int,
+	takeProfitBps int,
+) (*entity.Position, error) {
+	thresholds := entity.PositionThresholds{StopLossBps: stopLossBps, TakeProfitBps: takeProfitBps}
+	if !validThresholds(thresholds) {
+		return nil, entity.ErrInvalidPositionLimits
+	}
+
+	position, err := p.repo.Position(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case position.Status != entity.PositionStatusOpen:
+		return nil, entity.ErrPositionNotOpen
+	case !position.Held:
+		return nil, entity.ErrPositionNotHeld
+	}
+
+	position.PositionThresholds = thresholds
+	position.UpdatedAt = time.Now()
+	if err = p.repo.SavePosition(ctx, position, 0); err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+// resolveTrade attaches the sell a triggered position submitted, a position
+// whose sell failed is reported as failed rather than triggered.
+func (p *PositionService) resolveTrade(ctx context.Context, position *entity.Position) error {
+	if position.TradeID == "" {
+		return nil
+	}
+
+	trade, err := p.trades.Trade(ctx, position.TradeID)
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrNoTradesFound):
+		return nil
+	default:
+		return err
+	}
+
+	position.Trade = trade
+	switch trade.Status() {
+	case entity.TradeStatusFailed, entity.TradeStatusCancelled:
+		position.Status = entity.PositionStatusFailed
+		position.Error = trade.Error
+	}
+
+	return nil
+}
+
+func validThresholds(thresholds entity.PositionThresholds) bool {
+	return thresholds.StopLossBps >= 0 && thresholds.StopLossBps < _bpsDenominator && thresholds.TakeProfitBps >= 0
+}
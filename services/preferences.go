@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+type PreferencesService struct {
+	repo preferencesRepo
+}
+
+func NewPreferencesService(repo preferencesRepo) *PreferencesService {
+	return &PreferencesService{repo: repo}
+}
+
+func (p *PreferencesService) Preferences(ctx context.Context, owner common.Address) (*entity.Preferences, error) {
+	return ownerPreferences(ctx, p.repo, owner)
+}
+
+// SetHoldingMode changes where the owner's bought tokens go, recipient is
+// only taken for entity.HoldingModeRecipient.
+func (p *PreferencesService) SetHoldingMode(
+	ctx context.Context,
+	owner common.Address,
+	mode string,
+	recipient string,
+) (*entity.Preferences, error) {
+	switch mode {
+	case entity.HoldingModeForward, entity.HoldingModeHold:
+		if recipient != "" {
+			return nil, entity.ErrInvalidPreferences
+		}
+	case entity.HoldingModeRecipient:
+		if !common.IsHexAddress(recipient) || common.HexToAddress(recipient) == (common.Address{}) {
+			return nil, entity.ErrInvalidPreferences
+		}
+
+		recipient = common.HexToAddress(recipient).Hex()
+	default:
+		return nil, entity.ErrInvalidPreferences
+	}
+
+	preferences, err := ownerPreferences(ctx, p.repo, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences.HoldingMode = mode
+	preferences.Recipient = recipient
+	preferences.UpdatedAt = time.Now()
+	if err = p.repo.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// ownerPreferences returns the owner's preferences, owners that never set
+// any forward what they buy.
+func ownerPreferences(ctx context.Context, repo preferencesRepo, owner common.Address) (*entity.Preferences, error) {
+	preferences, err := repo.Preferences(ctx, owner)
+	switch {
+	case err == nil:
+		return preferences, nil
+	case errors.Is(err, entity.ErrNoPreferencesFound):
+		return &entity.Preferences{
+			Owner:       owner.Hex(),
+			HoldingMode: entity.HoldingModeForward,
+		}, nil
+	default:
+		return nil, err
+	}
+}
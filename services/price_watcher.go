@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/log"
+	"go.uber.org/zap"
+)
+
+var (
+	// _weth prices ETH itself, it is the wrapped native token on Base.
+	_weth = common.HexToAddress("0x4200000000000000000000000000000000000006")
+
+	_weiPerEth = new(big.Float).SetInt64(1e18)
+)
+
+// PriceWatcher polls the prices of tokens with open limit orders and turns
+// the orders whose limit is reached into trades.
+type PriceWatcher struct {
+	backend    *ethclient.Client
+	orders     ordersRepo
+	prices     tokenPricer
+	swapQuoter quoter
+	processor  tradeProcessor
+	limits     TradeLimits
+	interval   time.Duration
+	logger     log.Logger
+}
+
+func NewPriceWatcher(
+	backend *ethclient.Client,
+	orders ordersRepo,
+	prices tokenPricer,
+	swapQuoter quoter,
+	processor tradeProcessor,
+	limits TradeLimits,
+	interval time.Duration,
+	logger log.Logger,
+) *PriceWatcher {
+	return &PriceWatcher{
+		backend:    backend,
+		orders:     orders,
+		prices:     prices,
+		swapQuoter: swapQuoter,
+		processor:  processor,
+		limits:     limits,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+func (p *PriceWatcher) Run(ctx context.Context) {
+	go func() {
+		if err := p.orders.IndexOrders(ctx); err != nil {
+			p.logger.Error("failed to index orders", zap.Error(err))
+		}
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.poll(ctx); err != nil {
+					p.logger.Error("failed to poll orders", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+func (p *PriceWatcher) poll(ctx context.Context) error {
+	tokens, err := p.orders.OrderTokens(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		orders, err := p.orders.OpenOrders(ctx, token)
+		if err != nil {
+			p.logger.Error("failed to read orders", zap.String("token", token.Hex()), zap.Error(err))
+			continue
+		}
+
+		if len(orders) == 0 {
+			continue
+		}
+
+		price, err := p.prices.TokenPrice(ctx, token)
+		if err != nil {
+			p.logger.Error("failed to fetch price", zap.String("token", token.Hex()), zap.Error(err))
+			continue
+		}
+
+		for _, order := range orders {
+			limit, err := strconv.ParseFloat(order.LimitPrice, 64)
+			if err != nil || price > limit {
+				continue
+			}
+
+			if err = p.trigger(ctx, order, limit); err != nil {
+				p.logger.Error("failed to trigger order", zap.String("order", order.ID), zap.Error(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// trigger confirms the limit against an on-chain quote for the order's size
+// before claiming the order and submitting its trade. The claim is released
+// when the trade cannot be submitted, so the order stays open to retry.
+func (p *PriceWatcher) trigger(ctx context.Context, order *entity.LimitOrder, limit float64) error {
+	quoted, minBuyAmount, err := p.quotedPrice(ctx, order, limit)
+	if err != nil {
+		return err
+	}
+
+	if quoted > limit {
+		return nil
+	}
+
+	claimed, err := p.orders.ClaimOrder(ctx, order.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	controls, err := p.limits.Apply(entity.TradeControls{}, time.Now())
+	if err == nil {
+		err = p.processor.Submit(ctx, &entity.TradeRequest{
+			ID:            order.ID,
+			Owner:         order.Owner,
+			SellToken:     entity.NativeToken.Hex(),
+			BuyToken:      order.Token,
+			AmountIn:      order.EthIn,
+			MinBuyAmount:  minBuyAmount.String(),
+			TradeControls: controls,
+		})
+	}
+
+	if err != nil {
+		if releaseErr := p.orders.ReleaseOrder(ctx, order.ID); releaseErr != nil {
+			p.logger.Error("failed to release order", zap.String("order", order.ID), zap.Error(releaseErr))
+		}
+
+		return fmt.Errorf("failed to submit order trade, %w", err)
+	}
+
+	order.Status = entity.OrderStatusTriggered
+	order.TriggeredPrice = strconv.FormatFloat(quoted, 'f', -1, 64)
+	order.TradeID = order.ID
+	order.UpdatedAt = time.Now()
+	return p.orders.SaveOrder(ctx, order, _closedOrderExpiry)
+}
+
+// quotedPrice is the USD price per token the order would pay if it executed
+// now, from a swap quote for EthIn priced at the current ETH price. It also
+// returns the least amount of token EthIn must buy to stay within limit, so
+// the trade is refused when the price moves past the limit before execution.
+func (p *PriceWatcher) quotedPrice(
+	ctx context.Context,
+	order *entity.LimitOrder,
+	limit float64,
+) (float64, *big.Int, error) {
+	token := common.HexToAddress(order.Token)
+	quote, err := p.swapQuoter.GetQuote(ctx, &entity.QuoteRequest{
+		SellToken:  entity.NativeToken,
+		BuyToken:   token,
+		SellAmount: order.EthIn,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	buyAmount, ok := new(big.Float).SetString(quote.BuyAmount)
+	if !ok || buyAmount.Sign() <= 0 {
+		return 0, nil, errors.New("quote has no buy amount")
+	}
+
+	ethIn, ok := new(big.Float).SetString(order.EthIn)
+	if !ok {
+		return 0, nil, fmt.Errorf("failed to parse order amount %q", order.EthIn)
+	}
+
+	binding, err := entity.NewErc20Binding(token, p.backend)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	decimals, err := binding.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	ethPrice, err := p.prices.TokenPrice(ctx, _weth)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	unit := big.NewFloat(math.Pow10(int(decimals)))
+	tokens := new(big.Float).Quo(buyAmount, unit)
+	spent := new(big.Float).Mul(new(big.Float).Quo(ethIn, _weiPerEth), big.NewFloat(ethPrice))
+	price, _ := new(big.Float).Quo(spent, tokens).Float64()
+
+	minBuyAmount, _ := new(big.Float).Mul(new(big.Float).Quo(spent, big.NewFloat(limit)), unit).Int(nil)
+	return price, minBuyAmount, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/rahul0tripathi/framecoiner/entity"
 	"github.com/rahul0tripathi/framecoiner/pkg/log"
 	"go.uber.org/zap"
@@ -26,16 +27,55 @@ const (
 	_queueTimeout         = time.Second * 4
 	_maxReceiptFetch      = 3
 	_receiptFetchInterval = time.Second * 2
+
+	_pendingTradeExpiry        = time.Hour * 24
+	_pendingLockRetryInterval  = time.Millisecond * 100
+	_pendingLockAttempts       = 50
+	_replacementFeeBumpPercent = 110
+
+	// _l1FeeBufferPercent covers the L1 base fee moving between pricing a max
+	// withdrawal and its inclusion.
+	_l1FeeBufferPercent = 110
+)
+
+var (
+	_baseFeeMultiplier = big.NewInt(2)
 )
 
+type ProcessorConfig struct {
+	ChainID string
+	// LegacyTx signs pre London gas price transactions for chains without
+	// dynamic fees.
+	LegacyTx bool
+	// MaxFeeCapGwei caps the max fee per gas of every transaction, empty
+	// leaves it uncapped.
+	MaxFeeCapGwei string
+	// ResolveInterval is how often pending trades are checked for a mined
+	// transaction, zero disables the check.
+	ResolveInterval time.Duration
+}
+
 type TradeProcessor struct {
 	backend    *ethclient.Client
 	manager    keyManager
 	repo       tradesRepo
 	swapQuoter quoter
+	nonces     *NonceManager
+	positions  positionsRepo
+	pending    pendingTradesRepo
+	l1Oracle   l1FeeOracle
+	tokens     tokenTracker
+	prefs      preferencesRepo
+	listeners  []tradeListener
 	jobs       chan *entity.TradeRequest
+	tracks     chan *entity.TrackRequest
+	resolves   chan *entity.PendingTrade
+	withdraws  chan *entity.WithdrawRequest
 	logger     log.Logger
 	chainID    *big.Int
+	interval   time.Duration
+	legacyTx   bool
+	maxFeeCap  *big.Int
 	erc20ABI   *abi.ABI
 }
 
@@ -44,14 +84,30 @@ func NewTradeProcessor(
 	repo tradesRepo,
 	swapQuoter quoter,
 	client *ethclient.Client,
+	nonces *NonceManager,
+	positions positionsRepo,
+	pending pendingTradesRepo,
+	l1Oracle l1FeeOracle,
+	tokens tokenTracker,
+	prefs preferencesRepo,
 	logger log.Logger,
-	chainID string,
+	cfg ProcessorConfig,
 ) (*TradeProcessor, error) {
-	chainIDInt, ok := new(big.Int).SetString(chainID, 10)
+	chainIDInt, ok := new(big.Int).SetString(cfg.ChainID, 10)
 	if !ok {
 		return nil, errors.New("failed to parse chainID")
 	}
 
+	var maxFeeCap *big.Int
+	if cfg.MaxFeeCapGwei != "" {
+		maxFeeCapGwei, ok := new(big.Float).SetString(cfg.MaxFeeCapGwei)
+		if !ok || maxFeeCapGwei.Sign() <= 0 {
+			return nil, errors.New("failed to parse max fee cap")
+		}
+
+		maxFeeCap, _ = new(big.Float).Mul(maxFeeCapGwei, gwei).Int(nil)
+	}
+
 	erc20ABI, err := abi.JSON(strings.NewReader(entity.Erc20BindingMetaData.ABI))
 	if err != nil {
 		return nil, err
@@ -61,18 +117,78 @@ func NewTradeProcessor(
 		manager:    manager,
 		repo:       repo,
 		swapQuoter: swapQuoter,
+		nonces:     nonces,
+		positions:  positions,
+		pending:    pending,
+		l1Oracle:   l1Oracle,
+		tokens:     tokens,
+		prefs:      prefs,
 		jobs:       make(chan *entity.TradeRequest, _tradesQueueBuffer),
+		tracks:     make(chan *entity.TrackRequest, _tradesQueueBuffer),
+		resolves:   make(chan *entity.PendingTrade, _tradesQueueBuffer),
+		withdraws:  make(chan *entity.WithdrawRequest, _tradesQueueBuffer),
 		backend:    client,
 		logger:     logger,
 		chainID:    chainIDInt,
+		interval:   cfg.ResolveInterval,
+		legacyTx:   cfg.LegacyTx,
+		maxFeeCap:  maxFeeCap,
 		erc20ABI:   &erc20ABI,
 	}, nil
 }
 
+// Subscribe registers listener for completed trades, listeners must be added
+// before Run.
+func (t *TradeProcessor) Subscribe(listener tradeListener) {
+	t.listeners = append(t.listeners, listener)
+}
+
 func (t *TradeProcessor) Run(ctx context.Context, workers int) {
 	for i := 0; i < workers; i++ {
 		go t.worker(ctx)
 	}
+
+	if t.interval > 0 {
+		go t.resolvePending(ctx)
+	}
+}
+
+// resolvePending periodically settles the pending trades that were mined
+// after their receipt polls gave up.
+func (t *TradeProcessor) resolvePending(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.pollPending(ctx); err != nil {
+				t.logger.Error("failed to poll pending trades", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (t *TradeProcessor) pollPending(ctx context.Context) error {
+	trades, err := t.pending.PendingTrades(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pending := range trades {
+		mined, receipt, err := t.minedTx(ctx, pending)
+		if err == nil && receipt != nil {
+			err = t.settle(ctx, common.HexToAddress(pending.Owner), pending.ID, mined, receipt)
+		}
+
+		if err != nil {
+			t.logger.Error("failed to resolve pending trade", zap.String("id", pending.ID), zap.Error(err))
+		}
+	}
+
+	return nil
 }
 
 func (t *TradeProcessor) Submit(ctx context.Context, job *entity.TradeRequest) error {
@@ -84,6 +200,27 @@ func (t *TradeProcessor) Submit(ctx context.Context, job *entity.TradeRequest) e
 	}
 }
 
+// Track queues a swap broadcast by the owner's wallet so its receipt is
+// awaited and the trade resolved the same way as relayed trades.
+func (t *TradeProcessor) Track(ctx context.Context, job *entity.TrackRequest) error {
+	select {
+	case <-time.After(_queueTimeout):
+		return errors.New("failed to queue job")
+	case t.tracks <- job:
+		return nil
+	}
+}
+
+// Withdraw queues sending ETH from the trading account to the owner.
+func (t *TradeProcessor) Withdraw(ctx context.Context, job *entity.WithdrawRequest) error {
+	select {
+	case <-time.After(_queueTimeout):
+		return errors.New("failed to queue job")
+	case t.withdraws <- job:
+		return nil
+	}
+}
+
 func (t *TradeProcessor) worker(ctx context.Context) {
 	for {
 		select {
@@ -94,168 +231,1065 @@ func (t *TradeProcessor) worker(ctx context.Context) {
 			if err != nil {
 				t.logger.Error("failed to execute job", zap.Any("job", job), zap.Error(err))
 			}
+		case job := <-t.tracks:
+			err := t.track(ctx, job)
+			if err != nil {
+				t.logger.Error("failed to track job", zap.Any("job", job), zap.Error(err))
+			}
+		case pending := <-t.resolves:
+			err := t.resolve(ctx, pending)
+			if err != nil {
+				t.logger.Error("failed to resolve pending trade", zap.String("id", pending.ID), zap.Error(err))
+			}
+		case job := <-t.withdraws:
+			err := t.withdraw(ctx, job)
+			if err != nil {
+				t.logger.Error("failed to withdraw", zap.Any("job", job), zap.Error(err))
+			}
 		}
 	}
 }
 
-func (t *TradeProcessor) trade(ctx context.Context, job *entity.TradeRequest) error {
+func (t *TradeProcessor) track(ctx context.Context, job *entity.TrackRequest) error {
+	owner := common.HexToAddress(job.Owner)
+	if err := t.waitForTransactionReceipt(ctx, common.HexToHash(job.TxnHash)); err != nil {
+		return t.repo.UpdateTrade(ctx, owner, &entity.Trade{
+			JobID:   job.JobID,
+			Owner:   job.Owner,
+			TxnHash: job.TxnHash,
+			Error:   fmt.Sprintf("failed to fetch receipt: %s", err.Error()),
+			Expiry:  time.Now().Add(_tradeJobExpiry),
+			Request: job.Request,
+			State:   entity.TradeStatusFailed,
+		})
+	}
+
+	return t.repo.UpdateTrade(ctx, owner, &entity.Trade{
+		JobID:   job.JobID,
+		Owner:   job.Owner,
+		TxnHash: job.TxnHash,
+		Error:   "",
+		Expiry:  time.Now().Add(_tradeJobExpiry),
+		Request: job.Request,
+		State:   entity.TradeStatusSuccess,
+	})
+}
+
+// withdraw sends ETH from the trading account, the recipient is always the
+// owner so a withdrawal can never pay out to anyone else.
+func (t *TradeProcessor) withdraw(ctx context.Context, job *entity.WithdrawRequest) error {
 	owner := common.HexToAddress(job.Owner)
+	request := entity.Quote{
+		To:                owner.Hex(),
+		Value:             job.Amount,
+		CallData:          "0x",
+		BuyTokenToEthRate: "0",
+	}
+
+	fail := func(err error) error {
+		return t.repo.UpdateTrade(ctx, owner, &entity.Trade{
+			Kind:    entity.TradeKindWithdraw,
+			Owner:   job.Owner,
+			TxnHash: "",
+			Error:   err.Error(),
+			Expiry:  time.Now().Add(_tradeJobExpiry),
+			Request: request,
+		})
+	}
+
 	if err := t.repo.UpdateTrade(ctx, owner, &entity.Trade{
+		Kind:    entity.TradeKindWithdraw,
 		Owner:   job.Owner,
 		TxnHash: "",
 		Error:   "",
 		Expiry:  time.Now().Add(_tradeJobExpiry),
-		Request: entity.Quote{},
+		Request: request,
 	}); err != nil {
 		return err
 	}
 
-	quote, err := t.swapQuoter.GetQuote(ctx, common.HexToAddress(job.ToToken), job.EthIn)
+	signer, err := t.manager.SigningAddress(ctx, owner)
+	if err != nil {
+		return fail(fmt.Errorf("failed to resolve trading account: %w", err))
+	}
+
+	var signed *types.Transaction
+	if job.Amount == entity.WithdrawMax {
+		signed, err = t.withdrawMax(ctx, owner)
+	} else {
+		signed, err = t.relayTx(ctx, owner, &request)
+	}
 	if err != nil {
-		err = t.repo.UpdateTrade(ctx, owner, &entity.Trade{
-			Owner:   job.Owner,
-			TxnHash: "",
-			Error:   fmt.Sprintf("failed to get quote: %s", err.Error()),
-			Expiry:  time.Now().Add(_tradeJobExpiry),
-			Request: entity.Quote{},
-		})
+		return fail(fmt.Errorf("failed to relay: %w", err))
+	}
+
+	// the withdrawal is resolved as a pending trade like a swap, so it can be
+	// sped up or cancelled and still settles when mined after the receipt
+	// polls give up.
+	request.Value = signed.Value().String()
+	pending := &entity.PendingTrade{
+		ID:        signed.Hash().Hex(),
+		Kind:      entity.TradeKindWithdraw,
+		Owner:     job.Owner,
+		Signer:    signer.Hex(),
+		Nonce:     signed.Nonce(),
+		To:        request.To,
+		Value:     request.Value,
+		Data:      request.CallData,
+		Gas:       signed.Gas(),
+		Txns:      []entity.TradeTx{tradeTx(signed, entity.TradeTxOriginal)},
+		Quote:     request,
+		CreatedAt: time.Now(),
+	}
+	if err = t.pending.SavePendingTrade(ctx, pending, _pendingTradeExpiry); err != nil {
+		return fail(fmt.Errorf("failed to record pending withdrawal: %w", err))
+	}
+
+	if err = t.repo.UpdateTrade(ctx, owner, submittedTrade(pending)); err != nil {
 		return err
 	}
 
-	hash, err := t.relay(ctx, owner, quote)
+	return t.resolve(ctx, pending)
+}
+
+// withdrawMax sends the signer's balance less the L2 execution fee at the
+// transaction's fee cap and the L1 data fee of posting it.
+func (t *TradeProcessor) withdrawMax(ctx context.Context, owner common.Address) (*types.Transaction, error) {
+	signer, err := t.manager.SigningAddress(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// the owner may be a contract wallet, so the transfer's gas is estimated
+	// rather than assumed.
+	gasLimit, err := t.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From:  signer,
+		To:    &owner,
+		Value: common.Big1,
+	})
 	if err != nil {
-		err = t.repo.UpdateTrade(ctx, owner, &entity.Trade{
-			Owner:   job.Owner,
-			TxnHash: "",
-			Error:   fmt.Sprintf("failed to relay: %s", err.Error()),
-			Expiry:  time.Now().Add(_tradeJobExpiry),
-			Request: *quote,
-		})
+		return nil, err
+	}
+
+	build := func(nonce uint64) (types.TxData, error) {
+		balance, err := t.backend.BalanceAt(ctx, signer, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		txData, err := t.buildTx(ctx, nonce, gasLimit, owner, balance, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// the data fee is priced on a signed draft so the signature bytes
+		// are accounted for.
+		draft, err := t.manager.SignTx(ctx, owner, types.NewTx(txData), t.chainID)
+		if err != nil {
+			return nil, err
+		}
+
+		l1Fee, err := t.l1Oracle.L1Fee(ctx, draft)
+		if err != nil {
+			return nil, err
+		}
+
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), draft.GasFeeCap())
+		fee.Add(fee, new(big.Int).Div(new(big.Int).Mul(l1Fee, big.NewInt(_l1FeeBufferPercent)), big.NewInt(100)))
+
+		value := new(big.Int).Sub(balance, fee)
+		if value.Sign() <= 0 {
+			return nil, entity.ErrNothingToWithdraw
+		}
+
+		switch tx := txData.(type) {
+		case *types.LegacyTx:
+			tx.Value = value
+		case *types.DynamicFeeTx:
+			tx.Value = value
+		}
+
+		return txData, nil
+	}
+
+	signed, err := t.send(ctx, owner, signer, build)
+	if isNonceTooLow(err) {
+		if err = t.nonces.Resync(ctx, signer); err != nil {
+			return nil, err
+		}
+
+		signed, err = t.send(ctx, owner, signer, build)
+	}
+
+	return signed, err
+}
+
+// trade swaps the job's SellToken into BuyToken from the trading account,
+// approving the quote's allowance target first when selling a token, and
+// forwards whatever was bought to the owner.
+func (t *TradeProcessor) trade(ctx context.Context, job *entity.TradeRequest) error {
+	owner := common.HexToAddress(job.Owner)
+	// requested stands in for the quote on records written before one exists.
+	requested := &entity.Quote{SellToken: job.SellToken, BuyToken: job.BuyToken}
+	if err := t.repo.UpdateTrade(ctx, owner, &entity.Trade{
+		JobID:   job.ID,
+		Owner:   job.Owner,
+		TxnHash: "",
+		Error:   "",
+		Expiry:  time.Now().Add(_tradeJobExpiry),
+		Request: *requested,
+	}); err != nil {
 		return err
 	}
 
-	if err = t.waitForTransactionReceipt(ctx, *hash); err != nil {
-		err = t.repo.UpdateTrade(ctx, owner, &entity.Trade{
+	fail := func(quote *entity.Quote, reason string, err error) error {
+		return t.repo.UpdateTrade(ctx, owner, &entity.Trade{
+			JobID:   job.ID,
 			Owner:   job.Owner,
 			TxnHash: "",
-			Error:   fmt.Sprintf("failed to fetch receipt: %s", err.Error()),
+			Error:   fmt.Sprintf("failed to %s: %s", reason, err.Error()),
 			Expiry:  time.Now().Add(_tradeJobExpiry),
 			Request: *quote,
 		})
-		return err
 	}
 
-	flushHash, err := t.flush(ctx, job)
+	signer, err := t.manager.SigningAddress(ctx, owner)
 	if err != nil {
-		err = t.repo.UpdateTrade(ctx, owner, &entity.Trade{
-			Owner:   job.Owner,
-			TxnHash: "",
-			Error:   fmt.Sprintf("failed to flush: %s", err.Error()),
-			Expiry:  time.Now().Add(_tradeJobExpiry),
-			Request: *quote,
-		})
-		return err
+		return fail(requested, "resolve trading account", err)
 	}
 
-	if flushHash == nil {
-		flushHash = &entity.ZeroHash
+	sellToken := common.HexToAddress(job.SellToken)
+	buyToken := common.HexToAddress(job.BuyToken)
+	for _, traded := range []common.Address{sellToken, buyToken} {
+		if traded == entity.NativeToken {
+			continue
+		}
+
+		if err = t.tokens.TrackToken(ctx, owner, traded); err != nil {
+			t.logger.Error("failed to track token", zap.String("token", traded.Hex()), zap.Error(err))
+		}
 	}
 
-	return t.repo.UpdateTrade(ctx, owner, &entity.Trade{
-		Owner:   job.Owner,
-		TxnHash: hash.Hex(),
-		Error:   "",
-		Expiry:  time.Now().Add(_tradeJobExpiry),
-		Request: *quote,
+	var token *entity.Erc20Binding
+	amount := new(big.Int)
+	if sellToken == entity.NativeToken {
+		if _, ok := amount.SetString(job.AmountIn, 10); !ok {
+			return fail(requested, "resolve sell amount", entity.ErrInvalidSellAmount)
+		}
+	} else {
+		if token, err = entity.NewErc20Binding(sellToken, t.backend); err != nil {
+			return fail(requested, "bind token", err)
+		}
+
+		if amount, err = t.sellAmount(ctx, token, signer, job); err != nil {
+			return fail(requested, "resolve sell amount", err)
+		}
+	}
+
+	quote, err := t.swapQuoter.GetQuote(ctx, &entity.QuoteRequest{
+		SellToken:         sellToken,
+		BuyToken:          buyToken,
+		SellAmount:        amount.String(),
+		SlippageBps:       job.SlippageBps,
+		MaxPriceImpactBps: job.MaxPriceImpactBps,
 	})
-}
+	if err != nil {
+		return fail(requested, "get quote", err)
+	}
 
-func (t *TradeProcessor) waitForTransactionReceipt(ctx context.Context, txHash common.Hash) error {
-	checkStatus := func(receipt *types.Receipt) error {
-		if receipt.Status == 0 {
-			return errors.New("transaction failed")
+	if err = checkTradeControls(job, quote); err != nil {
+		return fail(quote, "check quote", err)
+	}
+
+	if token != nil && quote.AllowanceTarget != "" {
+		if err = t.approve(ctx, owner, signer, token, sellToken, common.HexToAddress(quote.AllowanceTarget), amount); err != nil {
+			return fail(quote, "approve", err)
 		}
+	}
 
-		return nil
+	before, err := t.backend.BalanceAt(ctx, signer, nil)
+	if err != nil {
+		return fail(quote, "fetch balance", err)
+	}
+
+	isBuy := sellToken == entity.NativeToken
+	var bought *entity.Erc20Binding
+	var boughtBefore *big.Int
+	if isBuy {
+		if bought, err = entity.NewErc20Binding(buyToken, t.backend); err != nil {
+			return fail(quote, "bind token", err)
+		}
+
+		if boughtBefore, err = bought.BalanceOf(&bind.CallOpts{Context: ctx}, signer); err != nil {
+			return fail(quote, "fetch balance", err)
+		}
+	}
+
+	signed, err := t.relayTx(ctx, owner, quote)
+	if err != nil {
+		return fail(quote, "relay", err)
+	}
+
+	pending := &entity.PendingTrade{
+		ID:         signed.Hash().Hex(),
+		Owner:      job.Owner,
+		Signer:     signer.Hex(),
+		Nonce:      signed.Nonce(),
+		To:         quote.To,
+		Value:      quote.Value,
+		Data:       quote.CallData,
+		Gas:        signed.Gas(),
+		Txns:       []entity.TradeTx{tradeTx(signed, entity.TradeTxOriginal)},
+		Job:        *job,
+		Quote:      *quote,
+		SellAmount: amount.String(),
+		EthBefore:  before.String(),
+		CreatedAt:  time.Now(),
+	}
+	if boughtBefore != nil {
+		pending.BoughtBefore = boughtBefore.String()
+	}
+
+	if err = t.pending.SavePendingTrade(ctx, pending, _pendingTradeExpiry); err != nil {
+		return fail(quote, "record pending trade", err)
 	}
 
+	if err = t.repo.UpdateTrade(ctx, owner, submittedTrade(pending)); err != nil {
+		return err
+	}
+
+	return t.resolve(ctx, pending)
+}
+
+// resolve waits for one of the pending trade's transactions to be mined and
+// settles the trade by it. A trade still unmined after the receipt polls
+// stays submitted, resolvePending settles it once mined.
+func (t *TradeProcessor) resolve(ctx context.Context, pending *entity.PendingTrade) error {
+	owner := common.HexToAddress(pending.Owner)
 	for i := 0; i < _maxReceiptFetch; i++ {
-		receipt, err := t.backend.TransactionReceipt(ctx, txHash)
+		current, err := t.pending.PendingTrade(ctx, owner, pending.ID)
 		switch {
 		case err == nil:
-			return checkStatus(receipt)
-		case errors.Is(err, ethereum.NotFound):
-			<-time.After(_receiptFetchInterval)
-			continue
+		case errors.Is(err, entity.ErrNoPendingTrade):
+			return nil
 		default:
 			return err
 		}
+
+		mined, receipt, err := t.minedTx(ctx, current)
+		if err != nil {
+			return err
+		}
+
+		if receipt != nil {
+			return t.settle(ctx, owner, pending.ID, mined, receipt)
+		}
+
+		<-time.After(_receiptFetchInterval)
 	}
 
-	return errors.New("failed to fetch receipt")
+	t.logger.Warn("pending trade not mined yet", zap.String("id", pending.ID))
+	return nil
 }
 
-func (t *TradeProcessor) flush(
+// minedTx returns the transaction of the pending trade that was mined, the
+// receipt is nil while none of them is.
+func (t *TradeProcessor) minedTx(
 	ctx context.Context,
-	job *entity.TradeRequest,
-) (*common.Hash, error) {
-	signer, err := t.manager.SigningAddress(ctx, common.HexToAddress(job.Owner))
-	if err != nil {
-		return nil, err
+	pending *entity.PendingTrade,
+) (*entity.TradeTx, *types.Receipt, error) {
+	for i := range pending.Txns {
+		receipt, err := t.backend.TransactionReceipt(ctx, common.HexToHash(pending.Txns[i].Hash))
+		switch {
+		case err == nil:
+			return &pending.Txns[i], receipt, nil
+		case errors.Is(err, ethereum.NotFound):
+		default:
+			return nil, nil, err
+		}
 	}
 
-	token, err := entity.NewErc20Binding(common.HexToAddress(job.ToToken), t.backend)
-	if err != nil {
-		return nil, err
+	return nil, nil, nil
+}
+
+// settle finishes a pending trade once mined was mined. The pending trade is
+// claimed first so concurrent resolvers settle it exactly once.
+func (t *TradeProcessor) settle(
+	ctx context.Context,
+	owner common.Address,
+	id string,
+	mined *entity.TradeTx,
+	receipt *types.Receipt,
+) error {
+	var pending *entity.PendingTrade
+	err := t.withPendingTrade(ctx, owner, id, func(claimed *entity.PendingTrade) error {
+		pending = claimed
+		return t.pending.DeletePendingTrade(ctx, owner, id)
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, entity.ErrNoPendingTrade):
+		return nil
+	default:
+		return err
 	}
 
-	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, signer)
-	if err != nil {
-		return nil, err
+	trade := &entity.Trade{
+		Kind:    pending.Kind,
+		JobID:   pending.Job.ID,
+		Owner:   pending.Owner,
+		TxnHash: mined.Hash,
+		Error:   "",
+		Expiry:  time.Now().Add(_tradeJobExpiry),
+		Request: pending.Quote,
+		Txns:    pending.Txns,
 	}
 
-	if balance.String() == "0" {
-		return nil, nil
+	fail := func(reason string, err error) error {
+		trade.Error = fmt.Sprintf("failed to %s: %s", reason, err.Error())
+		return t.repo.UpdateTrade(ctx, owner, trade)
 	}
 
-	callData, err := t.erc20ABI.Pack("transfer", common.HexToAddress(job.Owner), balance)
-	if err != nil {
-		return nil, err
+	switch {
+	case mined.Kind == entity.TradeTxCancel:
+		trade.State = entity.TradeStatusCancelled
+		return t.repo.UpdateTrade(ctx, owner, trade)
+	case receipt.Status == types.ReceiptStatusFailed:
+		return fail("fetch receipt", errors.New("transaction failed"))
+	case pending.Kind == entity.TradeKindWithdraw:
+		// a withdrawal is done once mined, nothing was bought to forward.
+		return t.repo.UpdateTrade(ctx, owner, trade)
 	}
 
-	return t.relay(ctx, common.HexToAddress(job.Owner), &entity.Quote{
-		To:                job.ToToken,
-		Value:             "0",
-		CallData:          hexutil.Encode(callData),
-		BuyTokenToEthRate: "0",
-	})
-}
+	job := &pending.Job
+	hash := common.HexToHash(mined.Hash)
+	signer := common.HexToAddress(pending.Signer)
+	buyToken := common.HexToAddress(job.BuyToken)
 
-func (t *TradeProcessor) relay(ctx context.Context, owner common.Address, quote *entity.Quote) (*common.Hash, error) {
-	signer, err := t.manager.SigningAddress(ctx, owner)
-	if err != nil {
-		return nil, err
+	// the swap is mined whatever the preferences say, when they can not be
+	// loaded the tokens stay in the trading account rather than risk sending
+	// them where the owner did not ask.
+	hold, recipient, prefsErr := t.holding(ctx, owner, job)
+	if prefsErr != nil {
+		t.logger.Warn("failed to load preferences, holding tokens", zap.String("txn", mined.Hash), zap.Error(prefsErr))
+		hold, recipient = true, owner
 	}
 
-	nonce, err := t.backend.PendingNonceAt(ctx, signer)
-	if err != nil {
-		return nil, err
+	if boughtBefore, ok := new(big.Int).SetString(pending.BoughtBefore, 10); ok {
+		ethIn, _ := new(big.Int).SetString(pending.SellAmount, 10)
+		bought, err := entity.NewErc20Binding(buyToken, t.backend)
+		if err == nil {
+			err = t.recordPosition(ctx, job, hash, ethIn, bought, signer, boughtBefore, hold)
+		}
+
+		if err != nil {
+			t.logger.Error("failed to record position", zap.String("txn", mined.Hash), zap.Error(err))
+		}
 	}
 
-	gasPrice, err := t.backend.SuggestGasPrice(ctx)
-	if err != nil {
-		return nil, err
+	before, ok := new(big.Int).SetString(pending.EthBefore, 10)
+	if !ok {
+		return fail("flush", errors.New("failed to parse balance"))
 	}
 
-	target := common.HexToAddress(quote.To)
-	data, err := hexutil.Decode(quote.CallData)
+	switch {
+	case hold:
+	case buyToken == entity.NativeToken:
+		_, err = t.forwardProceeds(ctx, owner, signer, before)
+	default:
+		_, err = t.flush(ctx, owner, recipient, signer, buyToken)
+	}
 	if err != nil {
-		return nil, err
+		return fail("flush", err)
 	}
 
-	value, ok := new(big.Int).SetString(quote.Value, 10)
-	if !ok {
-		return nil, errors.New("failed to parse value ")
+	for _, listener := range t.listeners {
+		go listener.TradeCompleted(ctx, job, hash)
+	}
+
+	return t.repo.UpdateTrade(ctx, owner, trade)
+}
+
+// holding decides whether the job's bought tokens stay in the trading account
+// and where they are forwarded otherwise. A job's own Hold or thresholds win
+// over the owner's preferences, which only apply to bought tokens.
+func (t *TradeProcessor) holding(
+	ctx context.Context,
+	owner common.Address,
+	job *entity.TradeRequest,
+) (bool, common.Address, error) {
+	switch {
+	case common.HexToAddress(job.BuyToken) == entity.NativeToken:
+		return job.Hold, owner, nil
+	case job.Hold, job.StopLossBps != 0, job.TakeProfitBps != 0:
+		return true, owner, nil
+	}
+
+	preferences, err := ownerPreferences(ctx, t.prefs, owner)
+	if err != nil {
+		return false, owner, err
+	}
+
+	switch preferences.HoldingMode {
+	case entity.HoldingModeHold:
+		return true, owner, nil
+	case entity.HoldingModeRecipient:
+		return false, common.HexToAddress(preferences.Recipient), nil
+	default:
+		return false, owner, nil
+	}
+}
+
+// Replace re-signs a pending trade at its nonce with bumped fees. A speed-up
+// resends the latest payload, a cancel sends nothing to the signer itself so
+// the nonce is used up without swapping. Once cancelled, speeding up bumps
+// the cancel.
+func (t *TradeProcessor) Replace(
+	ctx context.Context,
+	owner common.Address,
+	id string,
+	kind string,
+) (*entity.Trade, error) {
+	if kind != entity.TradeTxSpeedUp && kind != entity.TradeTxCancel {
+		return nil, fmt.Errorf("unknown replacement %q", kind)
+	}
+
+	var trade *entity.Trade
+	var resolvable *entity.PendingTrade
+	err := t.withPendingTrade(ctx, owner, id, func(pending *entity.PendingTrade) error {
+		resolvable = pending
+
+		_, receipt, err := t.minedTx(ctx, pending)
+		switch {
+		case err != nil:
+			return err
+		case receipt != nil:
+			return entity.ErrTradeNotPending
+		}
+
+		latest := pending.Txns[len(pending.Txns)-1]
+		if latest.Kind == entity.TradeTxCancel {
+			kind = entity.TradeTxCancel
+		}
+
+		signed, err := t.replaceTx(ctx, owner, pending, &latest, kind == entity.TradeTxCancel)
+		switch {
+		case isNonceTooLow(err):
+			return entity.ErrTradeNotPending
+		case err != nil:
+			return err
+		}
+
+		pending.Txns = append(pending.Txns, tradeTx(signed, kind))
+		if err = t.pending.SavePendingTrade(ctx, pending, _pendingTradeExpiry); err != nil {
+			return err
+		}
+
+		trade = submittedTrade(pending)
+		return t.repo.UpdateTrade(ctx, owner, trade)
+	})
+
+	if resolvable != nil {
+		t.queueResolve(resolvable)
+	}
+
+	return trade, err
+}
+
+// queueResolve hands pending to the workers without waiting, a trade that
+// does not fit the queue is left to resolvePending.
+func (t *TradeProcessor) queueResolve(pending *entity.PendingTrade) {
+	select {
+	case t.resolves <- pending:
+	default:
+		t.logger.Warn("resolve queue full, leaving pending trade to the poller", zap.String("id", pending.ID))
+	}
+}
+
+// replaceTx signs and broadcasts a replacement of latest at the pending
+// trade's nonce.
+func (t *TradeProcessor) replaceTx(
+	ctx context.Context,
+	owner common.Address,
+	pending *entity.PendingTrade,
+	latest *entity.TradeTx,
+	cancel bool,
+) (*types.Transaction, error) {
+	target := common.HexToAddress(pending.To)
+	value, ok := new(big.Int).SetString(pending.Value, 10)
+	if !ok {
+		return nil, errors.New("failed to parse value")
+	}
+
+	data, err := hexutil.Decode(pending.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	gas := pending.Gas
+	if cancel {
+		target, value, data, gas = common.HexToAddress(pending.Signer), new(big.Int), nil, params.TxGas
+	}
+
+	tipCap, feeCap, err := t.replacementFees(ctx, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	var txData types.TxData = &types.DynamicFeeTx{
+		ChainID:   t.chainID,
+		Nonce:     pending.Nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        &target,
+		Value:     value,
+		Data:      data,
+	}
+	if t.legacyTx {
+		txData = &types.LegacyTx{
+			Nonce:    pending.Nonce,
+			GasPrice: feeCap,
+			Gas:      gas,
+			To:       &target,
+			Value:    value,
+			Data:     data,
+		}
+	}
+
+	signed, err := t.manager.SignTx(ctx, owner, types.NewTx(txData), t.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = t.backend.SendTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// replacementFees returns the current fees or latest's bumped past the
+// node's replacement threshold, whichever are higher.
+func (t *TradeProcessor) replacementFees(ctx context.Context, latest *entity.TradeTx) (*big.Int, *big.Int, error) {
+	var tipCap, feeCap *big.Int
+	if t.legacyTx {
+		gasPrice, err := t.backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tipCap, feeCap = gasPrice, gasPrice
+	} else {
+		var err error
+		if tipCap, feeCap, err = t.suggestDynamicFees(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	bump := func(current *big.Int, previous string) *big.Int {
+		bumped, ok := new(big.Int).SetString(previous, 10)
+		if !ok {
+			return current
+		}
+
+		bumped.Div(bumped.Mul(bumped, big.NewInt(_replacementFeeBumpPercent)), big.NewInt(100))
+		bumped.Add(bumped, common.Big1)
+		if bumped.Cmp(current) < 0 {
+			return current
+		}
+
+		return bumped
+	}
+
+	tipCap = bump(tipCap, latest.GasTipCap)
+	feeCap = bump(feeCap, latest.GasFeeCap)
+	if tipCap.Cmp(feeCap) > 0 {
+		feeCap = tipCap
+	}
+
+	if t.maxFeeCap != nil && feeCap.Cmp(t.maxFeeCap) > 0 {
+		return nil, nil, fmt.Errorf("replacement fee cap %s exceeds max fee cap %s", feeCap, t.maxFeeCap)
+	}
+
+	return tipCap, feeCap, nil
+}
+
+// withPendingTrade runs update on the pending trade while holding its lock.
+func (t *TradeProcessor) withPendingTrade(
+	ctx context.Context,
+	owner common.Address,
+	id string,
+	update func(pending *entity.PendingTrade) error,
+) error {
+	token, err := acquireLock(ctx, _pendingLockRetryInterval, _pendingLockAttempts, func() (string, error) {
+		return t.pending.Lock(ctx, owner, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = t.pending.Unlock(context.Background(), owner, id, token)
+	}()
+
+	pending, err := t.pending.PendingTrade(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+
+	return update(pending)
+}
+
+// submittedTrade is the trade record of a relayed swap or withdrawal that is
+// not mined yet, TxnHash is the pending trade's ID to speed it up or cancel it
+// by.
+func submittedTrade(pending *entity.PendingTrade) *entity.Trade {
+	return &entity.Trade{
+		Kind:    pending.Kind,
+		JobID:   pending.Job.ID,
+		Owner:   pending.Owner,
+		TxnHash: pending.ID,
+		Error:   "",
+		Expiry:  time.Now().Add(_tradeJobExpiry),
+		Request: pending.Quote,
+		State:   entity.TradeStatusSubmitted,
+		Txns:    pending.Txns,
+	}
+}
+
+func tradeTx(signed *types.Transaction, kind string) entity.TradeTx {
+	return entity.TradeTx{
+		Hash:      signed.Hash().Hex(),
+		Kind:      kind,
+		GasTipCap: signed.GasTipCap().String(),
+		GasFeeCap: signed.GasFeeCap().String(),
+		SentAt:    time.Now(),
+	}
+}
+
+// checkTradeControls refuses a fresh quote that breaks the job's controls or
+// no longer honours the terms the owner confirmed.
+func checkTradeControls(job *entity.TradeRequest, quote *entity.Quote) error {
+	if job.Deadline != 0 && time.Now().Unix() > job.Deadline {
+		return entity.ErrQuoteExpired
+	}
+
+	slippageBps := job.SlippageBps
+	if slippageBps == 0 {
+		slippageBps = entity.DefaultSlippageBps
+	}
+
+	maxPriceImpactBps := job.MaxPriceImpactBps
+	if maxPriceImpactBps == 0 {
+		maxPriceImpactBps = entity.DefaultMaxPriceImpactBps
+	}
+
+	// estimatedPriceImpact is a percentage, slippage is how far the
+	// guaranteed price sits below the quoted price.
+	if impact, ok := new(big.Float).SetString(quote.EstimatedPriceImpact); ok {
+		if impact.Mul(impact, big.NewFloat(100)).Cmp(big.NewFloat(float64(maxPriceImpactBps))) > 0 {
+			return entity.ErrPriceImpactTooHigh
+		}
+	}
+
+	price, priceOk := new(big.Float).SetString(quote.Price)
+	guaranteed, guaranteedOk := new(big.Float).SetString(quote.GuaranteedPrice)
+	if priceOk && guaranteedOk && price.Sign() > 0 {
+		slippage := new(big.Float).Quo(new(big.Float).Sub(price, guaranteed), price)
+		if slippage.Mul(slippage, big.NewFloat(_bpsDenominator)).Cmp(big.NewFloat(float64(slippageBps))) > 0 {
+			return entity.ErrSlippageTooHigh
+		}
+	}
+
+	if job.MinBuyAmount == "" {
+		return nil
+	}
+
+	minBuyAmount, ok := new(big.Int).SetString(job.MinBuyAmount, 10)
+	if !ok {
+		return fmt.Errorf("failed to parse min buy amount %q", job.MinBuyAmount)
+	}
+
+	buyAmount, ok := new(big.Int).SetString(quote.BuyAmount, 10)
+	if !ok || buyAmount.Cmp(minBuyAmount) < 0 {
+		return entity.ErrQuoteBelowMinimum
+	}
+
+	return nil
+}
+
+func (t *TradeProcessor) sellAmount(
+	ctx context.Context,
+	token *entity.Erc20Binding,
+	signer common.Address,
+	job *entity.TradeRequest,
+) (*big.Int, error) {
+	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := new(big.Int)
+	if job.AmountIn != "" {
+		if _, ok := amount.SetString(job.AmountIn, 10); !ok {
+			return nil, entity.ErrInvalidSellAmount
+		}
+	} else {
+		amount.Div(new(big.Int).Mul(balance, big.NewInt(int64(job.Percent))), big.NewInt(100))
+	}
+
+	switch {
+	case amount.Sign() <= 0:
+		return nil, entity.ErrNothingToSell
+	case amount.Cmp(balance) > 0:
+		return nil, fmt.Errorf("sell amount %s exceeds balance %s", amount, balance)
+	}
+
+	return amount, nil
+}
+
+// approve raises the signer's allowance for spender to amount when the
+// current allowance does not cover it and waits for it to be mined.
+func (t *TradeProcessor) approve(
+	ctx context.Context,
+	owner common.Address,
+	signer common.Address,
+	token *entity.Erc20Binding,
+	tokenAddress common.Address,
+	spender common.Address,
+	amount *big.Int,
+) error {
+	allowance, err := token.Allowance(&bind.CallOpts{Context: ctx}, signer, spender)
+	if err != nil {
+		return err
+	}
+
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	callData, err := t.erc20ABI.Pack("approve", spender, amount)
+	if err != nil {
+		return err
+	}
+
+	hash, err := t.relay(ctx, owner, &entity.Quote{
+		To:                tokenAddress.Hex(),
+		Value:             "0",
+		CallData:          hexutil.Encode(callData),
+		BuyTokenToEthRate: "0",
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.waitForTransactionReceipt(ctx, *hash)
+}
+
+// forwardProceeds sends the ETH the signer gained since before to the owner,
+// the gas of the swap is already deducted so only the net proceeds move.
+func (t *TradeProcessor) forwardProceeds(
+	ctx context.Context,
+	owner common.Address,
+	signer common.Address,
+	before *big.Int,
+) (*common.Hash, error) {
+	after, err := t.backend.BalanceAt(ctx, signer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	proceeds := new(big.Int).Sub(after, before)
+	if proceeds.Sign() <= 0 {
+		return nil, nil
+	}
+
+	return t.relay(ctx, owner, &entity.Quote{
+		To:                owner.Hex(),
+		Value:             proceeds.String(),
+		CallData:          "0x",
+		BuyTokenToEthRate: "0",
+	})
+}
+
+// recordPosition records the buy from what the swap actually moved, the
+// received amount is the signer's balance change across the swap.
+func (t *TradeProcessor) recordPosition(
+	ctx context.Context,
+	job *entity.TradeRequest,
+	hash common.Hash,
+	ethIn *big.Int,
+	token *entity.Erc20Binding,
+	signer common.Address,
+	before *big.Int,
+	held bool,
+) error {
+	after, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, signer)
+	if err != nil {
+		return err
+	}
+
+	received := new(big.Int).Sub(after, before)
+	if received.Sign() <= 0 {
+		return errors.New("swap received no tokens")
+	}
+
+	decimals, err := token.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+
+	eth := new(big.Float).Quo(new(big.Float).SetInt(ethIn), _weiPerEth)
+	tokens := new(big.Float).Quo(new(big.Float).SetInt(received), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+
+	now := time.Now()
+	return t.positions.SavePosition(ctx, &entity.Position{
+		ID:                 hash.Hex(),
+		Owner:              job.Owner,
+		Token:              job.BuyToken,
+		EthIn:              ethIn.String(),
+		Amount:             received.String(),
+		EntryPrice:         new(big.Float).Quo(eth, tokens).Text('g', 10),
+		Held:               held,
+		PositionThresholds: job.PositionThresholds,
+		Status:             entity.PositionStatusOpen,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}, 0)
+}
+
+func (t *TradeProcessor) waitForTransactionReceipt(ctx context.Context, txHash common.Hash) error {
+	checkStatus := func(receipt *types.Receipt) error {
+		if receipt.Status == 0 {
+			return errors.New("transaction failed")
+		}
+
+		return nil
+	}
+
+	for i := 0; i < _maxReceiptFetch; i++ {
+		receipt, err := t.backend.TransactionReceipt(ctx, txHash)
+		switch {
+		case err == nil:
+			return checkStatus(receipt)
+		case errors.Is(err, ethereum.NotFound):
+			<-time.After(_receiptFetchInterval)
+			continue
+		default:
+			return err
+		}
+	}
+
+	return errors.New("failed to fetch receipt")
+}
+
+// flush forwards the signer's whole balance of token to recipient.
+func (t *TradeProcessor) flush(
+	ctx context.Context,
+	owner common.Address,
+	recipient common.Address,
+	signer common.Address,
+	tokenAddress common.Address,
+) (*common.Hash, error) {
+	token, err := entity.NewErc20Binding(tokenAddress, t.backend)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if balance.String() == "0" {
+		return nil, nil
+	}
+
+	return t.transfer(ctx, owner, recipient, tokenAddress, balance)
+}
+
+// SweepToken transfers the signer's whole balance of token to recipient and
+// waits for it to be mined, nothing is sent for a zero balance.
+func (t *TradeProcessor) SweepToken(
+	ctx context.Context,
+	owner common.Address,
+	recipient common.Address,
+	tokenAddress common.Address,
+) (*big.Int, *common.Hash, error) {
+	signer, err := t.manager.SigningAddress(ctx, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, err := entity.NewErc20Binding(tokenAddress, t.backend)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	balance, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, signer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if balance.Sign() == 0 {
+		return balance, nil, nil
+	}
+
+	hash, err := t.transfer(ctx, owner, recipient, tokenAddress, balance)
+	if err != nil {
+		return balance, nil, err
+	}
+
+	return balance, hash, t.waitForTransactionReceipt(ctx, *hash)
+}
+
+// transfer sends amount of token from the owner's signer to recipient.
+func (t *TradeProcessor) transfer(
+	ctx context.Context,
+	owner common.Address,
+	recipient common.Address,
+	tokenAddress common.Address,
+	amount *big.Int,
+) (*common.Hash, error) {
+	callData, err := t.erc20ABI.Pack("transfer", recipient, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.relay(ctx, owner, &entity.Quote{
+		To:                tokenAddress.Hex(),
+		Value:             "0",
+		CallData:          hexutil.Encode(callData),
+		BuyTokenToEthRate: "0",
+	})
+}
+
+func (t *TradeProcessor) relay(ctx context.Context, owner common.Address, quote *entity.Quote) (*common.Hash, error) {
+	signed, err := t.relayTx(ctx, owner, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := signed.Hash()
+	return &hash, nil
+}
+
+func (t *TradeProcessor) relayTx(ctx context.Context, owner common.Address, quote *entity.Quote) (*types.Transaction, error) {
+	signer, err := t.manager.SigningAddress(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	target := common.HexToAddress(quote.To)
+	data, err := hexutil.Decode(quote.CallData)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := new(big.Int).SetString(quote.Value, 10)
+	if !ok {
+		return nil, errors.New("failed to parse value ")
 	}
 
 	gasLimit, err := t.backend.EstimateGas(ctx, ethereum.CallMsg{
@@ -268,24 +1302,137 @@ func (t *TradeProcessor) relay(ctx context.Context, owner common.Address, quote
 		return nil, err
 	}
 
-	signed, err := t.manager.SignTx(ctx, owner, types.NewTx(
-		&types.LegacyTx{
+	build := func(nonce uint64) (types.TxData, error) {
+		return t.buildTx(ctx, nonce, gasLimit, target, value, data)
+	}
+
+	signed, err := t.send(ctx, owner, signer, build)
+	if isNonceTooLow(err) {
+		if err = t.nonces.Resync(ctx, signer); err != nil {
+			return nil, err
+		}
+
+		signed, err = t.send(ctx, owner, signer, build)
+	}
+
+	return signed, err
+}
+
+// send signs and broadcasts the transaction build returns for a reserved
+// nonce, the nonce is released again when the transaction never reached the
+// network.
+func (t *TradeProcessor) send(
+	ctx context.Context,
+	owner common.Address,
+	signer common.Address,
+	build func(nonce uint64) (types.TxData, error),
+) (*types.Transaction, error) {
+	nonce, err := t.nonces.Reserve(ctx, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := func() (*types.Transaction, error) {
+		txData, err := build(nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		signed, err := t.manager.SignTx(ctx, owner, types.NewTx(txData), t.chainID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = t.backend.SendTransaction(ctx, signed); err != nil {
+			return nil, err
+		}
+
+		return signed, nil
+	}()
+	if err != nil && !isNonceTooLow(err) {
+		if releaseErr := t.nonces.Release(ctx, signer, nonce); releaseErr != nil {
+			t.logger.Error("failed to release nonce", zap.String("signer", signer.Hex()), zap.Error(releaseErr))
+		}
+	}
+
+	return signed, err
+}
+
+func (t *TradeProcessor) buildTx(
+	ctx context.Context,
+	nonce uint64,
+	gasLimit uint64,
+	target common.Address,
+	value *big.Int,
+	data []byte,
+) (types.TxData, error) {
+	if t.legacyTx {
+		gasPrice, err := t.backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.maxFeeCap != nil && gasPrice.Cmp(t.maxFeeCap) > 0 {
+			return nil, fmt.Errorf("gas price %s exceeds max fee cap %s", gasPrice, t.maxFeeCap)
+		}
+
+		return &types.LegacyTx{
 			Nonce:    nonce,
 			GasPrice: gasPrice,
 			Gas:      gasLimit,
 			To:       &target,
 			Value:    value,
 			Data:     data,
-		},
-	), t.chainID)
+		}, nil
+	}
+
+	tipCap, feeCap, err := t.suggestDynamicFees(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = t.backend.SendTransaction(ctx, signed); err != nil {
-		return nil, err
+	return &types.DynamicFeeTx{
+		ChainID:   t.chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &target,
+		Value:     value,
+		Data:      data,
+	}, nil
+}
+
+// suggestDynamicFees returns the tip and a fee cap that covers the base fee
+// doubling, bounded by the configured max fee cap.
+func (t *TradeProcessor) suggestDynamicFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	tipCap, err := t.backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	hash := signed.Hash()
-	return &hash, nil
+	head, err := t.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if head.BaseFee == nil {
+		return nil, nil, errors.New("chain has no base fee, use legacy transactions")
+	}
+
+	feeCap := new(big.Int).Add(new(big.Int).Mul(head.BaseFee, _baseFeeMultiplier), tipCap)
+	if t.maxFeeCap == nil || feeCap.Cmp(t.maxFeeCap) <= 0 {
+		return tipCap, feeCap, nil
+	}
+
+	if head.BaseFee.Cmp(t.maxFeeCap) >= 0 {
+		return nil, nil, fmt.Errorf("base fee %s exceeds max fee cap %s", head.BaseFee, t.maxFeeCap)
+	}
+
+	feeCap = new(big.Int).Set(t.maxFeeCap)
+	if tipCap.Cmp(new(big.Int).Sub(feeCap, head.BaseFee)) > 0 {
+		tipCap = new(big.Int).Sub(feeCap, head.BaseFee)
+	}
+
+	return tipCap, feeCap, nil
 }
@@ -0,0 +1,828 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+	"github.com/rahul0tripathi/framecoiner/repo"
+	"go.uber.org/zap"
+)
+
+// stubChain answers the JSON-RPC calls of the processor from fixed values
+// and records the transactions it is sent.
+type stubChain struct {
+	mu       sync.Mutex
+	tipCap   *big.Int
+	baseFee  *big.Int
+	gasPrice *big.Int
+	balances []*big.Int
+	call     []byte
+	receipts map[common.Hash]*types.Receipt
+	sent     []*types.Transaction
+}
+
+type stubChainAPI struct {
+	chain *stubChain
+}
+
+func (a *stubChainAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(_testChainID))
+}
+
+func (a *stubChainAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(a.chain.tipCap)
+}
+
+func (a *stubChainAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(a.chain.gasPrice)
+}
+
+func (a *stubChainAPI) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	return &types.Header{
+		Difficulty: new(big.Int),
+		Number:     big.NewInt(1),
+		BaseFee:    a.chain.baseFee,
+	}
+}
+
+// GetBalance returns the queued balances in order, repeating the last one.
+func (a *stubChainAPI) GetBalance(address common.Address, block rpc.BlockNumberOrHash) *hexutil.Big {
+	a.chain.mu.Lock()
+	defer a.chain.mu.Unlock()
+
+	balance := a.chain.balances[0]
+	if len(a.chain.balances) > 1 {
+		a.chain.balances = a.chain.balances[1:]
+	}
+
+	return (*hexutil.Big)(balance)
+}
+
+func (a *stubChainAPI) Call(args map[string]interface{}, block *rpc.BlockNumberOrHash) hexutil.Bytes {
+	return a.chain.call
+}
+
+func (a *stubChainAPI) EstimateGas(args map[string]interface{}) hexutil.Uint64 {
+	return 50_000
+}
+
+func (a *stubChainAPI) GetTransactionCount(address common.Address, block rpc.BlockNumberOrHash) hexutil.Uint64 {
+	a.chain.mu.Lock()
+	defer a.chain.mu.Unlock()
+
+	return hexutil.Uint64(len(a.chain.sent))
+}
+
+func (a *stubChainAPI) SendRawTransaction(raw hexutil.Bytes) (common.Hash, error) {
+	transaction := new(types.Transaction)
+	if err := transaction.UnmarshalBinary(raw); err != nil {
+		return common.Hash{}, err
+	}
+
+	a.chain.mu.Lock()
+	defer a.chain.mu.Unlock()
+
+	a.chain.sent = append(a.chain.sent, transaction)
+	return transaction.Hash(), nil
+}
+
+func (a *stubChainAPI) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	a.chain.mu.Lock()
+	defer a.chain.mu.Unlock()
+
+	if receipt, ok := a.chain.receipts[hash]; ok {
+		return receipt
+	}
+
+	// every transaction the processor sends itself is mined straight away.
+	for _, transaction := range a.chain.sent {
+		if transaction.Hash() == hash {
+			return newTestReceipt(hash)
+		}
+	}
+
+	return nil
+}
+
+func (c *stubChain) transactions() []*types.Transaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*types.Transaction(nil), c.sent...)
+}
+
+func newTestReceipt(hash common.Hash) *types.Receipt {
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      hash,
+		Logs:        []*types.Log{},
+		BlockNumber: big.NewInt(1),
+	}
+}
+
+// stubKeyManager signs every owner's transactions with one in-memory key.
+type stubKeyManager struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *stubKeyManager) LookupSigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	return crypto.PubkeyToAddress(s.key.PublicKey), nil
+}
+
+func (s *stubKeyManager) SigningAddress(ctx context.Context, owner common.Address) (common.Address, error) {
+	return crypto.PubkeyToAddress(s.key.PublicKey), nil
+}
+
+func (s *stubKeyManager) SignTx(
+	ctx context.Context,
+	owner common.Address,
+	transaction *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	return types.SignTx(transaction, types.LatestSignerForChainID(chainID), s.key)
+}
+
+func newTestProcessor(t *testing.T, chain *stubChain, cfg ProcessorConfig) (*TradeProcessor, repo.Storage) {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &stubChainAPI{chain: chain}); err != nil {
+		t.Fatalf("failed to register eth api: %v", err)
+	}
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+
+	rpcClient, err := rpc.Dial(httpServer.URL)
+	if err != nil {
+		t.Fatalf("failed to dial stub chain: %v", err)
+	}
+
+	backend := ethclient.NewClient(rpcClient)
+	t.Cleanup(backend.Close)
+
+	redisServer := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: redisServer.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	cfg.ChainID = "8453"
+	processor, err := NewTradeProcessor(
+		&stubKeyManager{key: key},
+		repo.NewTradesRepo(storage),
+		nil,
+		backend,
+		NewNonceManager(repo.NewNoncesRepo(storage), backend),
+		repo.NewPositionsRepo(storage),
+		repo.NewPendingTradesRepo(storage),
+		nil,
+		nil,
+		repo.NewPreferencesRepo(storage),
+		zap.NewNop(),
+		cfg,
+	)
+	if err != nil {
+		t.Fatalf("failed to create processor: %v", err)
+	}
+
+	return processor, storage
+}
+
+func gweiInt(value int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(value), big.NewInt(1e9))
+}
+
+func TestSuggestDynamicFees(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseFee   *big.Int
+		maxFeeCap string
+		tipCap    *big.Int
+		feeCap    *big.Int
+		fails     bool
+	}{
+		{name: "uncapped", baseFee: gweiInt(10), tipCap: gweiInt(2), feeCap: gweiInt(22)},
+		{name: "under the cap", baseFee: gweiInt(10), maxFeeCap: "30", tipCap: gweiInt(2), feeCap: gweiInt(22)},
+		{name: "capped", baseFee: gweiInt(10), maxFeeCap: "15", tipCap: gweiInt(2), feeCap: gweiInt(15)},
+		{name: "capped tip", baseFee: gweiInt(10), maxFeeCap: "11", tipCap: gweiInt(1), feeCap: gweiInt(11)},
+		{name: "base fee over the cap", baseFee: gweiInt(10), maxFeeCap: "10", fails: true},
+		{name: "no base fee", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &stubChain{tipCap: gweiInt(2), baseFee: tt.baseFee}
+			processor, _ := newTestProcessor(t, chain, ProcessorConfig{MaxFeeCapGwei: tt.maxFeeCap})
+
+			tipCap, feeCap, err := processor.suggestDynamicFees(context.Background())
+			if tt.fails {
+				if err == nil {
+					t.Fatalf("expected an error, got tip %s and fee cap %s", tipCap, feeCap)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to suggest fees: %v", err)
+			}
+
+			if tipCap.Cmp(tt.tipCap) != 0 || feeCap.Cmp(tt.feeCap) != 0 {
+				t.Fatalf("expected tip %s and fee cap %s, got %s and %s", tt.tipCap, tt.feeCap, tipCap, feeCap)
+			}
+		})
+	}
+}
+
+func TestBuildTx(t *testing.T) {
+	target := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	tests := []struct {
+		name      string
+		legacy    bool
+		maxFeeCap string
+		fails     bool
+		check     func(t *testing.T, txData types.TxData)
+	}{
+		{
+			name: "dynamic fees",
+			check: func(t *testing.T, txData types.TxData) {
+				tx, ok := txData.(*types.DynamicFeeTx)
+				if !ok || tx.GasFeeCap.Cmp(gweiInt(22)) != 0 || tx.ChainID.Int64() != _testChainID || tx.Nonce != 3 {
+					t.Fatalf("unexpected dynamic fee transaction %+v", txData)
+				}
+			},
+		},
+		{
+			name:   "legacy",
+			legacy: true,
+			check: func(t *testing.T, txData types.TxData) {
+				tx, ok := txData.(*types.LegacyTx)
+				if !ok || tx.GasPrice.Cmp(gweiInt(5)) != 0 || tx.Nonce != 3 {
+					t.Fatalf("unexpected legacy transaction %+v", txData)
+				}
+			},
+		},
+		{name: "legacy over the cap", legacy: true, maxFeeCap: "4", fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &stubChain{tipCap: gweiInt(2), baseFee: gweiInt(10), gasPrice: gweiInt(5)}
+			processor, _ := newTestProcessor(t, chain, ProcessorConfig{LegacyTx: tt.legacy, MaxFeeCapGwei: tt.maxFeeCap})
+
+			txData, err := processor.buildTx(context.Background(), 3, 21_000, target, new(big.Int), nil)
+			if tt.fails {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", txData)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to build transaction: %v", err)
+			}
+
+			tt.check(t, txData)
+		})
+	}
+}
+
+func TestApprove(t *testing.T) {
+	token := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	spender := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000004")
+
+	tests := []struct {
+		name      string
+		allowance int64
+		approves  bool
+	}{
+		{name: "allowance covers the amount", allowance: 1000},
+		{name: "allowance exceeds the amount", allowance: 5000},
+		{name: "allowance short of the amount", allowance: 999, approves: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &stubChain{
+				tipCap:  gweiInt(1),
+				baseFee: gweiInt(1),
+				call:    common.LeftPadBytes(big.NewInt(tt.allowance).Bytes(), 32),
+			}
+			processor, _ := newTestProcessor(t, chain, ProcessorConfig{})
+
+			binding, err := entity.NewErc20Binding(token, processor.backend)
+			if err != nil {
+				t.Fatalf("failed to bind token: %v", err)
+			}
+
+			signer, _ := processor.manager.SigningAddress(context.Background(), owner)
+			err = processor.approve(context.Background(), owner, signer, binding, token, spender, big.NewInt(1000))
+			if err != nil {
+				t.Fatalf("failed to approve: %v", err)
+			}
+
+			sent := chain.transactions()
+			if !tt.approves {
+				if len(sent) != 0 {
+					t.Fatalf("expected no approval, sent %d transactions", len(sent))
+				}
+
+				return
+			}
+
+			expected, err := processor.erc20ABI.Pack("approve", spender, big.NewInt(1000))
+			if err != nil {
+				t.Fatalf("failed to pack approval: %v", err)
+			}
+
+			if len(sent) != 1 || *sent[0].To() != token || !bytes.Equal(sent[0].Data(), expected) {
+				t.Fatalf("expected one approval of the token, got %d transactions", len(sent))
+			}
+		})
+	}
+}
+
+func TestForwardProceeds(t *testing.T) {
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000004")
+
+	tests := []struct {
+		name     string
+		before   int64
+		after    int64
+		forwards int64
+	}{
+		{name: "gained", before: 1000, after: 4000, forwards: 3000},
+		{name: "unchanged", before: 1000, after: 1000},
+		{name: "lost to gas", before: 1000, after: 900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &stubChain{tipCap: gweiInt(1), baseFee: gweiInt(1), balances: []*big.Int{big.NewInt(tt.after)}}
+			processor, _ := newTestProcessor(t, chain, ProcessorConfig{})
+
+			signer, _ := processor.manager.SigningAddress(context.Background(), owner)
+			hash, err := processor.forwardProceeds(context.Background(), owner, signer, big.NewInt(tt.before))
+			if err != nil {
+				t.Fatalf("failed to forward proceeds: %v", err)
+			}
+
+			sent := chain.transactions()
+			if tt.forwards == 0 {
+				if hash != nil || len(sent) != 0 {
+					t.Fatalf("expected nothing forwarded, sent %d transactions", len(sent))
+				}
+
+				return
+			}
+
+			if len(sent) != 1 || *sent[0].To() != owner || sent[0].Value().Int64() != tt.forwards {
+				t.Fatalf("expected %d wei forwarded to the owner, got %d transactions", tt.forwards, len(sent))
+			}
+
+			if hash == nil || *hash != sent[0].Hash() {
+				t.Fatalf("expected the forwarded transaction hash, got %v", hash)
+			}
+		})
+	}
+}
+
+func TestSellAmount(t *testing.T) {
+	token := common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	tests := []struct {
+		name    string
+		job     entity.TradeRequest
+		balance int64
+		amount  int64
+		err     error
+		fails   bool
+	}{
+		{name: "exact amount", job: entity.TradeRequest{AmountIn: "400"}, balance: 1000, amount: 400},
+		{name: "percent of balance", job: entity.TradeRequest{Percent: 25}, balance: 1000, amount: 250},
+		{name: "whole balance", job: entity.TradeRequest{Percent: 100}, balance: 1000, amount: 1000},
+		{name: "empty balance", job: entity.TradeRequest{Percent: 50}, err: entity.ErrNothingToSell},
+		{name: "invalid amount", job: entity.TradeRequest{AmountIn: "many"}, balance: 1000, err: entity.ErrInvalidSellAmount},
+		{name: "over the balance", job: entity.TradeRequest{AmountIn: "1001"}, balance: 1000, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &stubChain{call: common.LeftPadBytes(big.NewInt(tt.balance).Bytes(), 32)}
+			processor, _ := newTestProcessor(t, chain, ProcessorConfig{})
+
+			binding, err := entity.NewErc20Binding(token, processor.backend)
+			if err != nil {
+				t.Fatalf("failed to bind token: %v", err)
+			}
+
+			amount, err := processor.sellAmount(context.Background(), binding, common.Address{}, &tt.job)
+			switch {
+			case tt.err != nil:
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+			case tt.fails:
+				if err == nil {
+					t.Fatalf("expected an error, got %s", amount)
+				}
+			case err != nil:
+				t.Fatalf("failed to size the sell: %v", err)
+			case amount.Int64() != tt.amount:
+				t.Fatalf("expected %d, got %s", tt.amount, amount)
+			}
+		})
+	}
+}
+
+func TestHolding(t *testing.T) {
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	token := "0x0000000000000000000000000000000000000002"
+	native := entity.NativeToken.Hex()
+
+	tests := []struct {
+		name        string
+		job         entity.TradeRequest
+		preferences *entity.Preferences
+		corrupt     bool
+		hold        bool
+		recipient   common.Address
+		fails       bool
+	}{
+		{name: "buy forwards by default", job: entity.TradeRequest{BuyToken: token}, recipient: owner},
+		{name: "buy held by the job", job: entity.TradeRequest{BuyToken: token, Hold: true}, hold: true, recipient: owner},
+		{
+			name:      "buy with a stop loss",
+			job:       entity.TradeRequest{BuyToken: token, PositionThresholds: entity.PositionThresholds{StopLossBps: 500}},
+			hold:      true,
+			recipient: owner,
+		},
+		{
+			name:      "buy with a take profit",
+			job:       entity.TradeRequest{BuyToken: token, PositionThresholds: entity.PositionThresholds{TakeProfitBps: 500}},
+			hold:      true,
+			recipient: owner,
+		},
+		{
+			name:        "buy held by preference",
+			job:         entity.TradeRequest{BuyToken: token},
+			preferences: &entity.Preferences{HoldingMode: entity.HoldingModeHold},
+			hold:        true,
+			recipient:   owner,
+		},
+		{
+			name:        "buy sent to the preferred recipient",
+			job:         entity.TradeRequest{BuyToken: token},
+			preferences: &entity.Preferences{HoldingMode: entity.HoldingModeRecipient, Recipient: recipient.Hex()},
+			recipient:   recipient,
+		},
+		{
+			name:        "job hold wins over a recipient",
+			job:         entity.TradeRequest{BuyToken: token, Hold: true},
+			preferences: &entity.Preferences{HoldingMode: entity.HoldingModeRecipient, Recipient: recipient.Hex()},
+			hold:        true,
+			recipient:   owner,
+		},
+		{
+			name:        "sell proceeds ignore preferences",
+			job:         entity.TradeRequest{BuyToken: native},
+			preferences: &entity.Preferences{HoldingMode: entity.HoldingModeRecipient, Recipient: recipient.Hex()},
+			recipient:   owner,
+		},
+		{name: "sell proceeds held by the job", job: entity.TradeRequest{BuyToken: native, Hold: true}, hold: true, recipient: owner},
+		{name: "unreadable preferences", job: entity.TradeRequest{BuyToken: token}, corrupt: true, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			processor, storage := newTestProcessor(t, &stubChain{}, ProcessorConfig{})
+
+			if tt.preferences != nil {
+				tt.preferences.Owner = owner.Hex()
+				if err := repo.NewPreferencesRepo(storage).SavePreferences(ctx, tt.preferences); err != nil {
+					t.Fatalf("failed to save preferences: %v", err)
+				}
+			}
+
+			if tt.corrupt {
+				if err := storage.Write(ctx, entity.KeyPreferences(owner), "{", 0); err != nil {
+					t.Fatalf("failed to corrupt preferences: %v", err)
+				}
+			}
+
+			hold, to, err := processor.holding(ctx, owner, &tt.job)
+			if tt.fails {
+				if err == nil {
+					t.Fatal("expected unreadable preferences to fail")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to decide holding: %v", err)
+			}
+
+			if hold != tt.hold || to != tt.recipient {
+				t.Fatalf("expected hold=%v to %s, got hold=%v to %s", tt.hold, tt.recipient.Hex(), hold, to.Hex())
+			}
+		})
+	}
+}
+
+func TestReplacementFees(t *testing.T) {
+	tests := []struct {
+		name      string
+		legacy    bool
+		maxFeeCap string
+		latest    entity.TradeTx
+		tipCap    *big.Int
+		feeCap    *big.Int
+		fails     bool
+	}{
+		{
+			name:   "current fees above the bump",
+			latest: entity.TradeTx{GasTipCap: gweiInt(1).String(), GasFeeCap: gweiInt(15).String()},
+			tipCap: gweiInt(2),
+			feeCap: gweiInt(22),
+		},
+		{
+			name:   "previous fees bumped",
+			latest: entity.TradeTx{GasTipCap: gweiInt(3).String(), GasFeeCap: gweiInt(30).String()},
+			tipCap: new(big.Int).Add(big.NewInt(3_300_000_000), common.Big1),
+			feeCap: new(big.Int).Add(gweiInt(33), common.Big1),
+		},
+		{
+			name:   "unparsable previous fees",
+			latest: entity.TradeTx{GasTipCap: "fast", GasFeeCap: ""},
+			tipCap: gweiInt(2),
+			feeCap: gweiInt(22),
+		},
+		{
+			name:   "bumped tip raises the fee cap",
+			latest: entity.TradeTx{GasTipCap: gweiInt(30).String(), GasFeeCap: gweiInt(20).String()},
+			tipCap: new(big.Int).Add(gweiInt(33), common.Big1),
+			feeCap: new(big.Int).Add(gweiInt(33), common.Big1),
+		},
+		{
+			name:      "bump over the max fee cap",
+			maxFeeCap: "25",
+			latest:    entity.TradeTx{GasTipCap: gweiInt(3).String(), GasFeeCap: gweiInt(30).String()},
+			fails:     true,
+		},
+		{
+			name:   "legacy gas price bumped",
+			legacy: true,
+			latest: entity.TradeTx{GasTipCap: gweiInt(10).String(), GasFeeCap: gweiInt(10).String()},
+			tipCap: new(big.Int).Add(gweiInt(11), common.Big1),
+			feeCap: new(big.Int).Add(gweiInt(11), common.Big1),
+		},
+		{
+			name:   "legacy current gas price",
+			legacy: true,
+			latest: entity.TradeTx{GasTipCap: gweiInt(1).String(), GasFeeCap: gweiInt(1).String()},
+			tipCap: gweiInt(5),
+			feeCap: gweiInt(5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &stubChain{tipCap: gweiInt(2), baseFee: gweiInt(10), gasPrice: gweiInt(5)}
+			processor, _ := newTestProcessor(t, chain, ProcessorConfig{LegacyTx: tt.legacy, MaxFeeCapGwei: tt.maxFeeCap})
+
+			tipCap, feeCap, err := processor.replacementFees(context.Background(), &tt.latest)
+			if tt.fails {
+				if err == nil {
+					t.Fatalf("expected an error, got tip %s and fee cap %s", tipCap, feeCap)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to bump fees: %v", err)
+			}
+
+			if tipCap.Cmp(tt.tipCap) != 0 || feeCap.Cmp(tt.feeCap) != 0 {
+				t.Fatalf("expected tip %s and fee cap %s, got %s and %s", tt.tipCap, tt.feeCap, tipCap, feeCap)
+			}
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	signer := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	target := common.HexToAddress("0x0000000000000000000000000000000000000006")
+	original := common.HexToHash("0x01")
+
+	tests := []struct {
+		name   string
+		kind   string
+		mined  bool
+		saved  bool
+		err    error
+		fails  bool
+		target common.Address
+	}{
+		{name: "speed up", kind: entity.TradeTxSpeedUp, saved: true, target: target},
+		{name: "cancel", kind: entity.TradeTxCancel, saved: true, target: signer},
+		{name: "mined trade", kind: entity.TradeTxSpeedUp, saved: true, mined: true, err: entity.ErrTradeNotPending},
+		{name: "unknown trade", kind: entity.TradeTxSpeedUp, err: entity.ErrNoPendingTrade},
+		{name: "unknown replacement", kind: "resubmit", saved: true, fails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			chain := &stubChain{tipCap: gweiInt(2), baseFee: gweiInt(10), receipts: map[common.Hash]*types.Receipt{}}
+			if tt.mined {
+				chain.receipts[original] = newTestReceipt(original)
+			}
+
+			processor, storage := newTestProcessor(t, chain, ProcessorConfig{})
+			if tt.saved {
+				pending := &entity.PendingTrade{
+					ID:     original.Hex(),
+					Owner:  owner.Hex(),
+					Signer: signer.Hex(),
+					Nonce:  7,
+					To:     target.Hex(),
+					Value:  "1000",
+					Data:   "0x",
+					Gas:    100_000,
+					Txns: []entity.TradeTx{{
+						Hash:      original.Hex(),
+						Kind:      entity.TradeTxOriginal,
+						GasTipCap: gweiInt(2).String(),
+						GasFeeCap: gweiInt(22).String(),
+					}},
+				}
+				if err := repo.NewPendingTradesRepo(storage).SavePendingTrade(ctx, pending, 0); err != nil {
+					t.Fatalf("failed to save pending trade: %v", err)
+				}
+			}
+
+			trade, err := processor.Replace(ctx, owner, original.Hex(), tt.kind)
+			switch {
+			case tt.err != nil:
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+			case tt.fails:
+				if err == nil {
+					t.Fatal("expected an unknown replacement to fail")
+				}
+			case err != nil:
+				t.Fatalf("failed to replace trade: %v", err)
+			}
+
+			sent := chain.transactions()
+			if tt.err != nil || tt.fails {
+				if len(sent) != 0 {
+					t.Fatalf("expected nothing to be sent, got %d transactions", len(sent))
+				}
+
+				return
+			}
+
+			if len(sent) != 1 || trade == nil {
+				t.Fatalf("expected one replacement and a trade, got %d transactions", len(sent))
+			}
+
+			replacement := sent[0]
+			if replacement.Nonce() != 7 || *replacement.To() != tt.target {
+				t.Fatalf("expected nonce 7 to %s, got nonce %d to %s", tt.target.Hex(), replacement.Nonce(), replacement.To().Hex())
+			}
+
+			bumped := new(big.Int).Add(big.NewInt(24_200_000_000), common.Big1)
+			if replacement.GasFeeCap().Cmp(bumped) != 0 {
+				t.Fatalf("expected fee cap %s, got %s", bumped, replacement.GasFeeCap())
+			}
+
+			stored, err := repo.NewPendingTradesRepo(storage).PendingTrade(ctx, owner, original.Hex())
+			if err != nil {
+				t.Fatalf("failed to read pending trade: %v", err)
+			}
+
+			if len(stored.Txns) != 2 || stored.Txns[1].Hash != replacement.Hash().Hex() || stored.Txns[1].Kind != tt.kind {
+				t.Fatalf("expected the replacement to be recorded, got %+v", stored.Txns)
+			}
+		})
+	}
+}
+
+func TestWithdraw(t *testing.T) {
+	ctx := context.Background()
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	chain := &stubChain{tipCap: gweiInt(2), baseFee: gweiInt(10)}
+	processor, storage := newTestProcessor(t, chain, ProcessorConfig{})
+
+	if err := processor.withdraw(ctx, &entity.WithdrawRequest{Owner: owner.Hex(), Amount: "1000"}); err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	sent := chain.transactions()
+	if len(sent) != 1 || *sent[0].To() != owner || sent[0].Value().String() != "1000" {
+		t.Fatalf("expected 1000 wei sent to the owner, got %d transactions", len(sent))
+	}
+
+	trade, err := repo.NewTradesRepo(storage).LatestTrade(ctx, owner)
+	if err != nil {
+		t.Fatalf("failed to read trade: %v", err)
+	}
+
+	if trade.Kind != entity.TradeKindWithdraw || trade.Status() != entity.TradeStatusSuccess || trade.TxnHash != sent[0].Hash().Hex() {
+		t.Fatalf("expected a settled withdrawal, got %+v", trade)
+	}
+
+	if _, err = repo.NewPendingTradesRepo(storage).PendingTrade(ctx, owner, sent[0].Hash().Hex()); !errors.Is(err, entity.ErrNoPendingTrade) {
+		t.Fatalf("expected the pending withdrawal to be settled, got %v", err)
+	}
+}
+
+func TestPollPendingSettlesWithdrawals(t *testing.T) {
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	signer := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	original := common.HexToHash("0x01")
+	cancel := common.HexToHash("0x02")
+
+	tests := []struct {
+		name   string
+		mined  common.Hash
+		status uint64
+		state  string
+	}{
+		{name: "mined late", mined: original, status: types.ReceiptStatusSuccessful, state: entity.TradeStatusSuccess},
+		{name: "reverted", mined: original, status: types.ReceiptStatusFailed, state: entity.TradeStatusFailed},
+		{name: "cancelled", mined: cancel, status: types.ReceiptStatusSuccessful, state: entity.TradeStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			receipt := newTestReceipt(tt.mined)
+			receipt.Status = tt.status
+			chain := &stubChain{receipts: map[common.Hash]*types.Receipt{tt.mined: receipt}}
+			processor, storage := newTestProcessor(t, chain, ProcessorConfig{})
+
+			pending := &entity.PendingTrade{
+				ID:     original.Hex(),
+				Kind:   entity.TradeKindWithdraw,
+				Owner:  owner.Hex(),
+				Signer: signer.Hex(),
+				To:     owner.Hex(),
+				Value:  "1000",
+				Data:   "0x",
+				Txns: []entity.TradeTx{
+					{Hash: original.Hex(), Kind: entity.TradeTxOriginal},
+					{Hash: cancel.Hex(), Kind: entity.TradeTxCancel},
+				},
+			}
+			if err := repo.NewPendingTradesRepo(storage).SavePendingTrade(ctx, pending, 0); err != nil {
+				t.Fatalf("failed to save pending withdrawal: %v", err)
+			}
+
+			if err := processor.pollPending(ctx); err != nil {
+				t.Fatalf("failed to poll pending trades: %v", err)
+			}
+
+			trade, err := repo.NewTradesRepo(storage).LatestTrade(ctx, owner)
+			if err != nil {
+				t.Fatalf("failed to read trade: %v", err)
+			}
+
+			if trade.Kind != entity.TradeKindWithdraw || trade.Status() != tt.state || trade.TxnHash != tt.mined.Hex() {
+				t.Fatalf("expected a %s withdrawal, got %+v", tt.state, trade)
+			}
+		})
+	}
+}
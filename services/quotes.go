@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_bpsDenominator     = 10000
+	_quoteIDLength      = 16
+	_quoteDomainName    = "FrameCoiner"
+	_quoteDomainVersion = "1"
+	_quotePrimaryType   = "TradeQuote"
+)
+
+// QuoteService previews swaps and places trades against previews their owner
+// confirmed with an EIP-712 signature.
+type QuoteService struct {
+	swapQuoter quoter
+	l1Oracle   l1FeeOracle
+	repo       quotesRepo
+	processor  tradeProcessor
+	limits     TradeLimits
+	chainID    *big.Int
+}
+
+func NewQuoteService(
+	swapQuoter quoter,
+	l1Oracle l1FeeOracle,
+	repo quotesRepo,
+	processor tradeProcessor,
+	limits TradeLimits,
+	chainID string,
+) (*QuoteService, error) {
+	chainIDInt, ok := new(big.Int).SetString(chainID, 10)
+	if !ok {
+		return nil, errors.New("failed to parse chainID")
+	}
+
+	return &QuoteService{
+		swapQuoter: swapQuoter,
+		l1Oracle:   l1Oracle,
+		repo:       repo,
+		processor:  processor,
+		limits:     limits,
+		chainID:    chainIDInt,
+	}, nil
+}
+
+// Preview quotes selling amount of sellToken for buyToken and saves the terms
+// for owner to sign, hold and thresholds are part of the signed terms.
+func (q *QuoteService) Preview(
+	ctx context.Context,
+	owner common.Address,
+	sellToken common.Address,
+	buyToken common.Address,
+	amount string,
+	controls entity.TradeControls,
+	hold bool,
+	thresholds entity.PositionThresholds,
+) (*entity.QuotePreview, error) {
+	if sellToken == buyToken {
+		return nil, entity.ErrInvalidSwapPair
+	}
+
+	if !validThresholds(thresholds) {
+		return nil, entity.ErrInvalidPositionLimits
+	}
+
+	controls, err := q.limits.Apply(controls, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sellAmount, ok := new(big.Int).SetString(amount, 10)
+	if !ok || sellAmount.Sign() <= 0 {
+		return nil, entity.ErrInvalidSellAmount
+	}
+
+	quote, err := q.swapQuoter.GetQuote(ctx, &entity.QuoteRequest{
+		SellToken:         sellToken,
+		BuyToken:          buyToken,
+		SellAmount:        sellAmount.String(),
+		SlippageBps:       controls.SlippageBps,
+		MaxPriceImpactBps: controls.MaxPriceImpactBps,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buyAmount := parseBigInt(quote.BuyAmount)
+	minBuyAmount := new(big.Int).Mul(buyAmount, big.NewInt(int64(_bpsDenominator-controls.SlippageBps)))
+	minBuyAmount.Div(minBuyAmount, big.NewInt(_bpsDenominator))
+
+	l2Fee := new(big.Int).Mul(parseBigInt(quote.EstimatedGas), parseBigInt(quote.GasPrice))
+	l1Fee, err := q.quoteL1Fee(ctx, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := quote.Sources
+	if sources == nil {
+		sources = []entity.QuoteSource{}
+	}
+
+	id := make([]byte, _quoteIDLength)
+	if _, err = rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	terms := &entity.QuoteTerms{
+		ID:                 hexutil.Encode(id),
+		Owner:              owner.Hex(),
+		SellToken:          sellToken.Hex(),
+		BuyToken:           buyToken.Hex(),
+		SellAmount:         sellAmount.String(),
+		MinBuyAmount:       minBuyAmount.String(),
+		Hold:               hold,
+		TradeControls:      controls,
+		PositionThresholds: thresholds,
+	}
+
+	hash, err := q.termsHash(terms)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = q.repo.SaveQuoteTerms(ctx, terms, time.Until(time.Unix(terms.Deadline, 0))); err != nil {
+		return nil, err
+	}
+
+	return &entity.QuotePreview{
+		SellToken:            sellToken.Hex(),
+		BuyToken:             buyToken.Hex(),
+		SellAmount:           sellAmount.String(),
+		BuyAmount:            buyAmount.String(),
+		MinBuyAmount:         minBuyAmount.String(),
+		SlippageBps:          controls.SlippageBps,
+		MaxPriceImpactBps:    controls.MaxPriceImpactBps,
+		Price:                quote.Price,
+		EstimatedPriceImpact: quote.EstimatedPriceImpact,
+		EstimatedGas:         parseBigInt(quote.EstimatedGas).String(),
+		L2Fee:                l2Fee.String(),
+		L1Fee:                l1Fee.String(),
+		TotalFee:             new(big.Int).Add(l2Fee, l1Fee).String(),
+		Sources:              sources,
+		QuoteID:              terms.ID,
+		QuoteHash:            hash.Hex(),
+		Deadline:             terms.Deadline,
+		Hold:                 hold,
+		StopLossBps:          thresholds.StopLossBps,
+		TakeProfitBps:        thresholds.TakeProfitBps,
+	}, nil
+}
+
+// ConfirmTrade places the trade previewed for owner under quoteID once
+// signature proves owner signed its terms. The terms are consumed only after
+// the signature checks out and can only be confirmed once.
+func (q *QuoteService) ConfirmTrade(ctx context.Context, owner common.Address, quoteID string, signature string) error {
+	terms, err := q.repo.QuoteTerms(ctx, quoteID)
+	if err != nil {
+		return err
+	}
+
+	if common.HexToAddress(terms.Owner) != owner {
+		return entity.ErrNoQuoteTerms
+	}
+
+	if time.Now().Unix() > terms.Deadline {
+		return entity.ErrQuoteExpired
+	}
+
+	hash, err := q.termsHash(terms)
+	if err != nil {
+		return err
+	}
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return entity.ErrInvalidQuoteSignature
+	}
+
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil || crypto.PubkeyToAddress(*publicKey) != owner {
+		return entity.ErrInvalidQuoteSignature
+	}
+
+	if err = q.repo.ConsumeQuoteTerms(ctx, quoteID); err != nil {
+		return err
+	}
+
+	return q.processor.Submit(ctx, &entity.TradeRequest{
+		Owner:              owner.Hex(),
+		SellToken:          terms.SellToken,
+		BuyToken:           terms.BuyToken,
+		AmountIn:           terms.SellAmount,
+		MinBuyAmount:       terms.MinBuyAmount,
+		Hold:               terms.Hold,
+		TradeControls:      terms.TradeControls,
+		PositionThresholds: terms.PositionThresholds,
+	})
+}
+
+func (q *QuoteService) termsHash(terms *entity.QuoteTerms) (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			_quotePrimaryType: {
+				{Name: "quoteId", Type: "string"},
+				{Name: "sellToken", Type: "address"},
+				{Name: "buyToken", Type: "address"},
+				{Name: "sellAmount", Type: "uint256"},
+				{Name: "minBuyAmount", Type: "uint256"},
+				{Name: "slippageBps", Type: "uint256"},
+				{Name: "maxPriceImpactBps", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+				{Name: "hold", Type: "bool"},
+				{Name: "stopLossBps", Type: "uint256"},
+				{Name: "takeProfitBps", Type: "uint256"},
+			},
+		},
+		PrimaryType: _quotePrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:    _quoteDomainName,
+			Version: _quoteDomainVersion,
+			ChainId: (*math.HexOrDecimal256)(q.chainID),
+		},
+		Message: apitypes.TypedDataMessage{
+			"quoteId":           terms.ID,
+			"sellToken":         terms.SellToken,
+			"buyToken":          terms.BuyToken,
+			"sellAmount":        terms.SellAmount,
+			"minBuyAmount":      terms.MinBuyAmount,
+			"slippageBps":       strconv.Itoa(terms.SlippageBps),
+			"maxPriceImpactBps": strconv.Itoa(terms.MaxPriceImpactBps),
+			"deadline":          strconv.FormatInt(terms.Deadline, 10),
+			"hold":              terms.Hold,
+			"stopLossBps":       strconv.Itoa(terms.StopLossBps),
+			"takeProfitBps":     strconv.Itoa(terms.TakeProfitBps),
+		},
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash quote terms, %w", err)
+	}
+
+	return common.BytesToHash(hash), nil
+}
+
+// quoteL1Fee prices the data fee of the unsigned swap transaction, close
+// enough for a preview since the signature adds a fixed size.
+func (q *QuoteService) quoteL1Fee(ctx context.Context, quote *entity.Quote) (*big.Int, error) {
+	data, err := hexutil.Decode(quote.CallData)
+	if err != nil {
+		return nil, err
+	}
+
+	to := common.HexToAddress(quote.To)
+	return q.l1Oracle.L1Fee(ctx, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   q.chainID,
+		Gas:       parseBigInt(quote.EstimatedGas).Uint64(),
+		GasFeeCap: parseBigInt(quote.GasPrice),
+		GasTipCap: new(big.Int),
+		To:        &to,
+		Value:     parseBigInt(quote.Value),
+		Data:      data,
+	}))
+}
+
+func parseBigInt(value string) *big.Int {
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return new(big.Int)
+	}
+
+	return parsed
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+func TestTermsHashCoversPositionTerms(t *testing.T) {
+	svc, err := NewQuoteService(nil, nil, nil, nil, TradeLimits{}, "8453")
+	if err != nil {
+		t.Fatalf("failed to create quote service: %v", err)
+	}
+
+	base := entity.QuoteTerms{
+		ID:           "0x01",
+		SellToken:    entity.NativeToken.Hex(),
+		BuyToken:     "0x0000000000000000000000000000000000000001",
+		SellAmount:   "1000",
+		MinBuyAmount: "900",
+	}
+
+	hash, err := svc.termsHash(&base)
+	if err != nil {
+		t.Fatalf("failed to hash terms: %v", err)
+	}
+
+	tests := map[string]func(terms *entity.QuoteTerms){
+		"hold":           func(terms *entity.QuoteTerms) { terms.Hold = true },
+		"stop loss":      func(terms *entity.QuoteTerms) { terms.StopLossBps = 500 },
+		"take profit":    func(terms *entity.QuoteTerms) { terms.TakeProfitBps = 500 },
+		"min buy amount": func(terms *entity.QuoteTerms) { terms.MinBuyAmount = "800" },
+	}
+
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			terms := base
+			modify(&terms)
+
+			modified, err := svc.termsHash(&terms)
+			if err != nil {
+				t.Fatalf("failed to hash terms: %v", err)
+			}
+
+			if modified == hash {
+				t.Fatalf("%s is not covered by the signed hash", name)
+			}
+		})
+	}
+}
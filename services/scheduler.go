@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/log"
+	"go.uber.org/zap"
+)
+
+const (
+	// _maxCatchUpRuns bounds how many missed slots a catch up schedule
+	// submits at once, older ones are skipped.
+	_maxCatchUpRuns = 5
+)
+
+// Scheduler submits the due runs of active schedules.
+type Scheduler struct {
+	schedules schedulesRepo
+	processor tradeProcessor
+	limits    TradeLimits
+	interval  time.Duration
+	logger    log.Logger
+}
+
+func NewScheduler(
+	schedules schedulesRepo,
+	processor tradeProcessor,
+	limits TradeLimits,
+	interval time.Duration,
+	logger log.Logger,
+) *Scheduler {
+	return &Scheduler{
+		schedules: schedules,
+		processor: processor,
+		limits:    limits,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.poll(ctx); err != nil {
+					s.logger.Error("failed to poll schedules", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) poll(ctx context.Context) error {
+	schedules, err := s.schedules.AllSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		if schedule.Status != entity.ScheduleStatusActive || schedule.NextRunAt.After(now) {
+			continue
+		}
+
+		if err = s.runDue(ctx, common.HexToAddress(schedule.Owner), schedule.ID, now); err != nil {
+			s.logger.Error("failed to run schedule", zap.String("schedule", schedule.ID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// runDue settles every slot of the schedule due by now, slots missed while
+// the scheduler was down are skipped or run according to the schedule's
+// missed run policy.
+func (s *Scheduler) runDue(ctx context.Context, owner common.Address, id string, now time.Time) error {
+	token, err := s.schedules.Lock(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	defer s.schedules.Unlock(ctx, id, token)
+
+	schedule, err := s.schedules.Schedule(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+
+	if schedule.Status != entity.ScheduleStatusActive || schedule.NextRunAt.After(now) {
+		return nil
+	}
+
+	due := int(now.Sub(schedule.NextRunAt)/schedule.Interval()) + 1
+	due = min(due, schedule.TotalRuns-schedule.CompletedRuns)
+	run := 1
+	if schedule.MissedRunPolicy == entity.MissedRunCatchUp {
+		run = min(due, _maxCatchUpRuns)
+	}
+
+	for i := 0; i < due; i++ {
+		result := entity.ScheduleRun{
+			Slot:   schedule.CompletedRuns + 1,
+			RunAt:  schedule.NextRunAt,
+			Status: entity.ScheduleRunSkipped,
+		}
+
+		if i >= due-run {
+			s.submit(ctx, schedule, &result)
+		}
+
+		schedule.Runs = append(schedule.Runs, result)
+		schedule.CompletedRuns++
+		schedule.NextRunAt = schedule.NextRunAt.Add(schedule.Interval())
+	}
+
+	if len(schedule.Runs) > _scheduleRunHistorySize {
+		schedule.Runs = schedule.Runs[len(schedule.Runs)-_scheduleRunHistorySize:]
+	}
+
+	if schedule.CompletedRuns >= schedule.TotalRuns {
+		schedule.Status = entity.ScheduleStatusCompleted
+	}
+
+	schedule.UpdatedAt = now
+	return s.schedules.SaveSchedule(ctx, schedule, scheduleExpiry(schedule))
+}
+
+func (s *Scheduler) submit(ctx context.Context, schedule *entity.Schedule, result *entity.ScheduleRun) {
+	result.TradeID = fmt.Sprintf("%s-%d", schedule.ID, result.Slot)
+	result.Status = entity.ScheduleRunSubmitted
+
+	controls, err := s.limits.Apply(entity.TradeControls{}, time.Now())
+	if err == nil {
+		err = s.processor.Submit(ctx, &entity.TradeRequest{
+			ID:            result.TradeID,
+			Owner:         schedule.Owner,
+			SellToken:     entity.NativeToken.Hex(),
+			BuyToken:      schedule.Token,
+			AmountIn:      schedule.EthIn,
+			TradeControls: controls,
+		})
+	}
+
+	if err != nil {
+		result.Status = entity.ScheduleRunFailed
+		result.TradeID = ""
+		result.Error = err.Error()
+	}
+}
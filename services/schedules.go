@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_scheduleIDLength       = 16
+	_minScheduleInterval    = time.Minute
+	_maxScheduleRuns        = 1000
+	_closedScheduleExpiry   = time.Hour * 24 * 7
+	_scheduleRunHistorySize = 50
+)
+
+type ScheduleService struct {
+	repo   schedulesRepo
+	trades tradesRepo
+}
+
+func NewScheduleService(repo schedulesRepo, trades tradesRepo) *ScheduleService {
+	return &ScheduleService{repo: repo, trades: trades}
+}
+
+func (s *ScheduleService) CreateSchedule(
+	ctx context.Context,
+	owner common.Address,
+	token common.Address,
+	ethIn string,
+	interval time.Duration,
+	runs int,
+	missedRunPolicy string,
+) (*entity.Schedule, error) {
+	amount, ok := new(big.Int).SetString(ethIn, 10)
+	if !ok || amount.Sign() <= 0 {
+		return nil, entity.ErrInvalidSellAmount
+	}
+
+	if missedRunPolicy == "" {
+		missedRunPolicy = entity.MissedRunSkip
+	}
+
+	switch {
+	case interval < _minScheduleInterval:
+		return nil, entity.ErrInvalidSchedule
+	case runs <= 0 || runs > _maxScheduleRuns:
+		return nil, entity.ErrInvalidSchedule
+	case missedRunPolicy != entity.MissedRunSkip && missedRunPolicy != entity.MissedRunCatchUp:
+		return nil, entity.ErrInvalidSchedule
+	}
+
+	id := make([]byte, _scheduleIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	schedule := &entity.Schedule{
+		ID:              hexutil.Encode(id),
+		Owner:           owner.Hex(),
+		Token:           token.Hex(),
+		EthIn:           amount.String(),
+		IntervalSeconds: int64(interval / time.Second),
+		TotalRuns:       runs,
+		MissedRunPolicy: missedRunPolicy,
+		NextRunAt:       now,
+		Status:          entity.ScheduleStatusActive,
+		Runs:            []entity.ScheduleRun{},
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := s.repo.SaveSchedule(ctx, schedule, 0); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *ScheduleService) Schedules(ctx context.Context, owner common.Address) ([]*entity.Schedule, error) {
+	return s.repo.Schedules(ctx, owner)
+}
+
+// Schedule returns the schedule with the trade record of every run that
+// still has one.
+func (s *ScheduleService) Schedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error) {
+	schedule, err := s.repo.Schedule(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range schedule.Runs {
+		if schedule.Runs[i].TradeID == "" {
+			continue
+		}
+
+		trade, err := s.trades.Trade(ctx, schedule.Runs[i].TradeID)
+		switch {
+		case err == nil:
+			schedule.Runs[i].Trade = trade
+		case errors.Is(err, entity.ErrNoTradesFound):
+		default:
+			return nil, err
+		}
+	}
+
+	return schedule, nil
+}
+
+func (s *ScheduleService) PauseSchedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error) {
+	return s.update(ctx, owner, id, func(schedule *entity.Schedule) error {
+		if schedule.Status != entity.ScheduleStatusActive {
+			return entity.ErrScheduleNotActive
+		}
+
+		schedule.Status = entity.ScheduleStatusPaused
+		return nil
+	})
+}
+
+// ResumeSchedule reactivates a paused schedule, time spent paused does not
+// count as missed runs.
+func (s *ScheduleService) ResumeSchedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error) {
+	return s.update(ctx, owner, id, func(schedule *entity.Schedule) error {
+		if schedule.Status != entity.ScheduleStatusPaused {
+			return entity.ErrScheduleNotPaused
+		}
+
+		schedule.Status = entity.ScheduleStatusActive
+		if now := time.Now(); schedule.NextRunAt.Before(now) {
+			schedule.NextRunAt = now
+		}
+
+		return nil
+	})
+}
+
+func (s *ScheduleService) CancelSchedule(ctx context.Context, owner common.Address, id string) (*entity.Schedule, error) {
+	return s.update(ctx, owner, id, func(schedule *entity.Schedule) error {
+		if schedule.Status != entity.ScheduleStatusActive && schedule.Status != entity.ScheduleStatusPaused {
+			return entity.ErrScheduleNotRunning
+		}
+
+		schedule.Status = entity.ScheduleStatusCancelled
+		return nil
+	})
+}
+
+func (s *ScheduleService) update(
+	ctx context.Context,
+	owner common.Address,
+	id string,
+	apply func(schedule *entity.Schedule) error,
+) (*entity.Schedule, error) {
+	token, err := s.repo.Lock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.repo.Unlock(ctx, id, token)
+
+	schedule, err := s.repo.Schedule(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apply(schedule); err != nil {
+		return nil, err
+	}
+
+	schedule.UpdatedAt = time.Now()
+	if err = s.repo.SaveSchedule(ctx, schedule, scheduleExpiry(schedule)); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// scheduleExpiry keeps running schedules until they end and ended ones for
+// a week.
+func scheduleExpiry(schedule *entity.Schedule) time.Duration {
+	switch schedule.Status {
+	case entity.ScheduleStatusActive, entity.ScheduleStatusPaused:
+		return 0
+	default:
+		return _closedScheduleExpiry
+	}
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/log"
+	"go.uber.org/zap"
+)
+
+const (
+	_sweepIDLength    = 16
+	_sweepExpiry      = time.Hour * 24 * 7
+	_sweepQueueBuffer = 10
+)
+
+type queuedSweep struct {
+	sweep     entity.Sweep
+	lockToken string
+}
+
+// SweepService moves stranded ERC-20 balances out of trading accounts. Tokens
+// are discovered from the account's past trades and the configured token
+// list, and each owner's sweep runs alone in the background. Every interval
+// the accounts that traded are swept as well.
+type SweepService struct {
+	repo      sweepsRepo
+	positions positionsRepo
+	pending   pendingTradesRepo
+	prefs     preferencesRepo
+	sweeper   tokenSweeper
+	tokens    []common.Address
+	queue     chan *queuedSweep
+	interval  time.Duration
+	logger    log.Logger
+}
+
+func NewSweepService(
+	repo sweepsRepo,
+	positions positionsRepo,
+	pending pendingTradesRepo,
+	prefs preferencesRepo,
+	sweeper tokenSweeper,
+	tokens []string,
+	interval time.Duration,
+	logger log.Logger,
+) (*SweepService, error) {
+	configured := make([]common.Address, 0, len(tokens))
+	for _, token := range tokens {
+		if !common.IsHexAddress(token) {
+			return nil, fmt.Errorf("invalid sweep token %q", token)
+		}
+
+		configured = append(configured, common.HexToAddress(token))
+	}
+
+	return &SweepService{
+		repo:      repo,
+		positions: positions,
+		pending:   pending,
+		prefs:     prefs,
+		sweeper:   sweeper,
+		tokens:    configured,
+		queue:     make(chan *queuedSweep, _sweepQueueBuffer),
+		interval:  interval,
+		logger:    logger,
+	}, nil
+}
+
+func (s *SweepService) Run(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case queued := <-s.queue:
+				if err := s.sweep(ctx, &queued.sweep, queued.lockToken); err != nil {
+					s.logger.Error("failed to sweep", zap.String("sweep", queued.sweep.ID), zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.poll(ctx); err != nil {
+					s.logger.Error("failed to poll sweeps", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// poll sweeps every account that traded, one after the other, to the owner
+// or their preferred recipient. Owners who hold by preference keep their
+// tokens, and owners already sweeping or with trades still pending are left
+// alone, the pending trades settle their tokens themselves.
+func (s *SweepService) poll(ctx context.Context) error {
+	owners, err := s.repo.Owners(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending, err := s.pending.PendingTrades(ctx)
+	if err != nil {
+		return err
+	}
+
+	trading := make([]common.Address, 0, len(pending))
+	for _, trade := range pending {
+		trading = append(trading, common.HexToAddress(trade.Owner))
+	}
+
+	for _, owner := range owners {
+		if slices.Contains(trading, owner) {
+			continue
+		}
+
+		preferences, err := ownerPreferences(ctx, s.prefs, owner)
+		switch {
+		case err != nil:
+			s.logger.Error("failed to load preferences", zap.String("owner", owner.Hex()), zap.Error(err))
+			continue
+		case preferences.HoldingMode == entity.HoldingModeHold:
+			continue
+		}
+
+		recipient := owner
+		if preferences.HoldingMode == entity.HoldingModeRecipient {
+			recipient = common.HexToAddress(preferences.Recipient)
+		}
+
+		token, err := s.repo.Lock(ctx, owner)
+		switch {
+		case err == nil:
+		case errors.Is(err, entity.ErrSweepInProgress):
+			continue
+		default:
+			s.logger.Error("failed to lock sweep", zap.String("owner", owner.Hex()), zap.Error(err))
+			continue
+		}
+
+		sweep, err := s.newSweep(ctx, owner, recipient)
+		if err == nil {
+			err = s.sweep(ctx, sweep, token)
+		} else {
+			_ = s.repo.Unlock(ctx, owner, token)
+		}
+
+		if err != nil {
+			s.logger.Error("failed to sweep", zap.String("owner", owner.Hex()), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// StartSweep queues sweeping every discovered token balance of the owner's
+// trading account to the owner.
+func (s *SweepService) StartSweep(ctx context.Context, owner common.Address) (*entity.Sweep, error) {
+	token, err := s.repo.Lock(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	sweep, err := s.queueSweep(ctx, owner, token)
+	if err != nil {
+		_ = s.repo.Unlock(ctx, owner, token)
+		return nil, err
+	}
+
+	return sweep, nil
+}
+
+func (s *SweepService) Sweep(ctx context.Context, owner common.Address, id string) (*entity.Sweep, error) {
+	return s.repo.Sweep(ctx, owner, id)
+}
+
+// queueSweep hands the sweep to the worker along with the token of the
+// owner's sweep lock, which the worker releases once the sweep is done.
+func (s *SweepService) queueSweep(ctx context.Context, owner common.Address, token string) (*entity.Sweep, error) {
+	sweep, err := s.newSweep(ctx, owner, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	queued := &queuedSweep{sweep: *sweep, lockToken: token}
+	queued.sweep.Results = slices.Clone(sweep.Results)
+	select {
+	case <-time.After(_queueTimeout):
+		return nil, errors.New("failed to queue sweep")
+	case s.queue <- queued:
+		return sweep, nil
+	}
+}
+
+// newSweep records a queued sweep of every discovered token of the owner's
+// trading account to recipient.
+func (s *SweepService) newSweep(
+	ctx context.Context,
+	owner common.Address,
+	recipient common.Address,
+) (*entity.Sweep, error) {
+	tokens, err := s.discover(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, _sweepIDLength)
+	if _, err = rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sweep := &entity.Sweep{
+		ID:        hexutil.Encode(id),
+		Owner:     owner.Hex(),
+		Recipient: recipient.Hex(),
+		Status:    entity.SweepStatusQueued,
+		Results:   make([]entity.SweepResult, 0, len(tokens)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, token := range tokens {
+		sweep.Results = append(sweep.Results, entity.SweepResult{
+			Token:  token.Hex(),
+			Status: entity.SweepResultPending,
+		})
+	}
+
+	if err = s.repo.SaveSweep(ctx, sweep, _sweepExpiry); err != nil {
+		return nil, err
+	}
+
+	return sweep, nil
+}
+
+// discover returns the tokens the trading account traded and the configured
+// ones, without duplicates.
+func (s *SweepService) discover(ctx context.Context, owner common.Address) ([]common.Address, error) {
+	traded, err := s.repo.Tokens(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]common.Address, 0, len(traded)+len(s.tokens))
+	for _, token := range append(traded, s.tokens...) {
+		if !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens, nil
+}
+
+func (s *SweepService) sweep(ctx context.Context, sweep *entity.Sweep, lockToken string) error {
+	owner := common.HexToAddress(sweep.Owner)
+	defer func() {
+		if err := s.repo.Unlock(context.Background(), owner, lockToken); err != nil {
+			s.logger.Error("failed to unlock sweep", zap.String("owner", sweep.Owner), zap.Error(err))
+		}
+	}()
+
+	save := func(status string) {
+		sweep.Status = status
+		sweep.UpdatedAt = time.Now()
+		if err := s.repo.SaveSweep(ctx, sweep, _sweepExpiry); err != nil {
+			s.logger.Error("failed to save sweep", zap.String("sweep", sweep.ID), zap.Error(err))
+		}
+	}
+
+	// tokens of open held positions stay in the account, the position
+	// monitor sells them from there.
+	held, err := s.heldTokens(ctx, owner)
+	if err != nil {
+		for i := range sweep.Results {
+			sweep.Results[i].Status = entity.SweepResultFailed
+			sweep.Results[i].Error = fmt.Sprintf("failed to load positions: %s", err.Error())
+		}
+
+		save(entity.SweepStatusDone)
+		return err
+	}
+
+	save(entity.SweepStatusRunning)
+	for i := range sweep.Results {
+		result := &sweep.Results[i]
+		token := common.HexToAddress(result.Token)
+		if slices.Contains(held, token) {
+			result.Status = entity.SweepResultHeld
+			continue
+		}
+
+		amount, hash, err := s.sweeper.SweepToken(ctx, owner, common.HexToAddress(sweep.Recipient), token)
+		switch {
+		case err != nil:
+			result.Status = entity.SweepResultFailed
+			result.Error = err.Error()
+		case hash == nil:
+			result.Status = entity.SweepResultEmpty
+		default:
+			result.Status = entity.SweepResultSwept
+		}
+
+		if amount != nil && amount.Sign() > 0 {
+			result.Amount = amount.String()
+		}
+
+		if hash != nil {
+			result.TxnHash = hash.Hex()
+		}
+
+		save(entity.SweepStatusRunning)
+	}
+
+	save(entity.SweepStatusDone)
+	return nil
+}
+
+func (s *SweepService) heldTokens(ctx context.Context, owner common.Address) ([]common.Address, error) {
+	positions, err := s.positions.Positions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make([]common.Address, 0)
+	for _, position := range positions {
+		if position.Held && position.Status == entity.PositionStatusOpen {
+			held = append(held, common.HexToAddress(position.Token))
+		}
+	}
+
+	return held, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rahul0tripathi/framecoiner/entity"
+	"github.com/rahul0tripathi/framecoiner/pkg/redis"
+	"github.com/rahul0tripathi/framecoiner/repo"
+	"go.uber.org/zap"
+)
+
+type sweptToken struct {
+	owner     common.Address
+	recipient common.Address
+	token     common.Address
+}
+
+// stubSweeper records every sweep instead of sending it.
+type stubSweeper struct {
+	mu    sync.Mutex
+	swept []sweptToken
+}
+
+func (s *stubSweeper) SweepToken(
+	ctx context.Context,
+	owner common.Address,
+	recipient common.Address,
+	token common.Address,
+) (*big.Int, *common.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.swept = append(s.swept, sweptToken{owner: owner, recipient: recipient, token: token})
+	hash := common.BigToHash(big.NewInt(int64(len(s.swept))))
+	return big.NewInt(1), &hash, nil
+}
+
+func TestSweepPoll(t *testing.T) {
+	ctx := context.Background()
+	token := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	forwarding := common.HexToAddress("0x0000000000000000000000000000000000000010")
+	redirecting := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	holding := common.HexToAddress("0x0000000000000000000000000000000000000012")
+	trading := common.HexToAddress("0x0000000000000000000000000000000000000013")
+
+	server := miniredis.RunT(t)
+	storage, err := redis.NewRedisDB(redis.RedisConfig{Addr: server.Addr()})
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	sweeps := repo.NewSweepsRepo(storage)
+	for _, owner := range []common.Address{forwarding, redirecting, holding, trading} {
+		if err = sweeps.TrackToken(ctx, owner, token); err != nil {
+			t.Fatalf("failed to track token: %v", err)
+		}
+	}
+
+	prefs := repo.NewPreferencesRepo(storage)
+	for _, preferences := range []*entity.Preferences{
+		{Owner: redirecting.Hex(), HoldingMode: entity.HoldingModeRecipient, Recipient: recipient.Hex()},
+		{Owner: holding.Hex(), HoldingMode: entity.HoldingModeHold},
+	} {
+		if err = prefs.SavePreferences(ctx, preferences); err != nil {
+			t.Fatalf("failed to save preferences: %v", err)
+		}
+	}
+
+	pending := repo.NewPendingTradesRepo(storage)
+	if err = pending.SavePendingTrade(ctx, &entity.PendingTrade{
+		ID:        common.HexToHash("0x01").Hex(),
+		Owner:     trading.Hex(),
+		CreatedAt: time.Now(),
+	}, 0); err != nil {
+		t.Fatalf("failed to save pending trade: %v", err)
+	}
+
+	sweeper := &stubSweeper{}
+	svc, err := NewSweepService(sweeps, repo.NewPositionsRepo(storage), pending, prefs, sweeper, nil, time.Minute, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create sweep service: %v", err)
+	}
+
+	if err = svc.poll(ctx); err != nil {
+		t.Fatalf("failed to poll sweeps: %v", err)
+	}
+
+	expected := map[common.Address]common.Address{forwarding: forwarding, redirecting: recipient}
+	if len(sweeper.swept) != len(expected) {
+		t.Fatalf("expected %d sweeps, got %+v", len(expected), sweeper.swept)
+	}
+
+	for _, swept := range sweeper.swept {
+		to, ok := expected[swept.owner]
+		if !ok || swept.recipient != to || swept.token != token {
+			t.Fatalf("unexpected sweep of %s from %s to %s", swept.token.Hex(), swept.owner.Hex(), swept.recipient.Hex())
+		}
+	}
+}
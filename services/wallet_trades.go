@@ -0,0 +1,196 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rahul0tripathi/framecoiner/entity"
+)
+
+const (
+	_methodSendTransaction = "eth_sendTransaction"
+
+	_walletTxLookupAttempts = 5
+	_walletTxLookupInterval = time.Second
+)
+
+// WalletTradeService backs the non-custodial mode, swaps are returned as
+// unsigned transactions for the owner's own wallet to send and only tracked
+// here once broadcast.
+type WalletTradeService struct {
+	swapQuoter quoter
+	repo       tradesRepo
+	processor  tradeProcessor
+	backend    *ethclient.Client
+	chainID    string
+}
+
+func NewWalletTradeService(
+	swapQuoter quoter,
+	repo tradesRepo,
+	processor tradeProcessor,
+	backend *ethclient.Client,
+	chainID string,
+) *WalletTradeService {
+	return &WalletTradeService{
+		swapQuoter: swapQuoter,
+		repo:       repo,
+		processor:  processor,
+		backend:    backend,
+		chainID:    chainID,
+	}
+}
+
+// BuildSwapTransaction quotes the swap for the owner's wallet and keeps it
+// under id, the frame the transaction was requested from, until the wallet
+// reports the hash it was broadcast under.
+func (w *WalletTradeService) BuildSwapTransaction(
+	ctx context.Context,
+	id string,
+	owner common.Address,
+	wallet common.Address,
+	tokenAddress common.Address,
+	ethIn string,
+) (*entity.FrameTransaction, error) {
+	// a frame is requoted on every press until its transaction is sent.
+	existing, err := w.repo.Trade(ctx, id)
+	switch {
+	case errors.Is(err, entity.ErrNoTradesFound):
+	case err != nil:
+		return nil, err
+	case existing.Owner != owner.Hex() || existing.State != entity.TradeStatusPending:
+		return nil, entity.ErrTradeNotPending
+	}
+
+	quote, err := w.swapQuoter.GetQuote(ctx, &entity.QuoteRequest{
+		SellToken:  entity.NativeToken,
+		BuyToken:   tokenAddress,
+		SellAmount: ethIn,
+		Taker:      wallet,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = w.repo.SaveTrade(ctx, &entity.Trade{
+		JobID:   id,
+		Owner:   owner.Hex(),
+		Expiry:  time.Now().Add(_tradeJobExpiry),
+		Request: *quote,
+		State:   entity.TradeStatusPending,
+		Wallet:  wallet.Hex(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &entity.FrameTransaction{
+		ChainID: fmt.Sprintf("eip155:%s", w.chainID),
+		Method:  _methodSendTransaction,
+		Params: entity.FrameTransactionParams{
+			ABI:   []any{},
+			To:    quote.To,
+			Data:  quote.CallData,
+			Value: quote.Value,
+		},
+	}, nil
+}
+
+// ConfirmSwapTransaction records the hash the wallet broadcast the swap quoted
+// under id as and starts tracking its receipt. The transaction must be the
+// quoted swap sent from the quoted wallet.
+func (w *WalletTradeService) ConfirmSwapTransaction(
+	ctx context.Context,
+	owner common.Address,
+	id string,
+	hash common.Hash,
+) error {
+	trade, err := w.repo.Trade(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if trade.Owner != owner.Hex() || trade.State != entity.TradeStatusPending || trade.Wallet == "" {
+		return entity.ErrNoTradesFound
+	}
+
+	transaction, err := w.transaction(ctx, hash)
+	if err != nil {
+		return err
+	}
+
+	if err = matchQuotedSwap(transaction, trade); err != nil {
+		return err
+	}
+
+	trade.TxnHash = hash.Hex()
+	trade.State = entity.TradeStatusSubmitted
+	trade.Expiry = time.Now().Add(_tradeJobExpiry)
+	if err = w.repo.UpdateTrade(ctx, owner, trade); err != nil {
+		return err
+	}
+
+	return w.processor.Track(ctx, &entity.TrackRequest{
+		JobID:   id,
+		Owner:   owner.Hex(),
+		TxnHash: hash.Hex(),
+		Request: trade.Request,
+	})
+}
+
+// transaction fetches the broadcast transaction, waiting briefly for it to
+// reach the node when the wallet sent it elsewhere.
+func (w *WalletTradeService) transaction(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	for i := 0; ; i++ {
+		transaction, _, err := w.backend.TransactionByHash(ctx, hash)
+		switch {
+		case err == nil:
+			return transaction, nil
+		case !errors.Is(err, ethereum.NotFound):
+			return nil, err
+		case i == _walletTxLookupAttempts-1:
+			return nil, fmt.Errorf("%w: transaction %s not found", entity.ErrTransactionMismatch, hash.Hex())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(_walletTxLookupInterval):
+		}
+	}
+}
+
+// matchQuotedSwap checks transaction is the swap quoted for the trade's
+// wallet, so a hash of any other transaction is never recorded as the trade.
+func matchQuotedSwap(transaction *types.Transaction, trade *entity.Trade) error {
+	from, err := types.Sender(types.LatestSignerForChainID(transaction.ChainId()), transaction)
+	if err != nil {
+		return fmt.Errorf("%w: %s", entity.ErrTransactionMismatch, err.Error())
+	}
+
+	quote := trade.Request
+	value, ok := new(big.Int).SetString(quote.Value, 10)
+	if !ok {
+		value = new(big.Int)
+	}
+
+	switch {
+	case from != common.HexToAddress(trade.Wallet):
+		return fmt.Errorf("%w: sent from %s", entity.ErrTransactionMismatch, from.Hex())
+	case transaction.To() == nil || *transaction.To() != common.HexToAddress(quote.To):
+		return fmt.Errorf("%w: sent to another contract", entity.ErrTransactionMismatch)
+	case !bytes.Equal(transaction.Data(), common.FromHex(quote.CallData)):
+		return fmt.Errorf("%w: calldata differs", entity.ErrTransactionMismatch)
+	case transaction.Value().Cmp(value) != 0:
+		return fmt.Errorf("%w: value differs", entity.ErrTransactionMismatch)
+	}
+
+	return nil
+}